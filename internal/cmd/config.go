@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	"github.com/veggiemonk/backlog/internal/paths"
+	"go.yaml.in/yaml/v4"
+)
+
+var configExample = `
+backlog config list                       # Effective configuration and where each value comes from
+backlog config get auto-commit            # Effective value of a key
+backlog config set auto-commit true       # Set a key in the repository config (.backlog/config.yaml)
+backlog config set log-level debug --user # Set a key in the user config
+backlog config list --json                # Print JSON output
+`
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and change the configuration",
+	Long: `Inspects and changes the configuration.
+Each key is read, by order of precedence, from its command-line flag, its BACKLOG_* environment variable,
+the repository config file (config.yaml in the tasks directory, checked in with the tasks),
+the user config file (backlog/config.yaml in the user config directory, e.g. ~/.config/backlog/config.yaml)
+and finally its default value.
+The folder key cannot be set in the repository config since it locates that file.
+
+The statuses and transitions keys define the workflow of the tasks, they are only read from the config files:
+
+  statuses: [todo, in-progress, review, blocked, done, cancelled]
+  transitions:           # statuses each status can change to, any status if not listed
+    todo: [in-progress, blocked, cancelled]
+    in-progress: [review, blocked, todo]
+    review: [in-progress, done]
+    blocked: [todo, in-progress]
+
+The fields key declares custom fields of the tasks, stored in their front matter,
+with a type (string, int, enum, date or list), the values of an enum and a description.
+They are set with --field on create and edit, and usable in the where queries and sort of list:
+
+  fields:
+    component: {type: enum, values: [api, cli, ui], description: Part of the codebase}
+    sprint: {type: int}
+    customer: {type: list}`,
+	Example: configExample,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a configuration key",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration key in the repository or user config file",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runConfigSet,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the effective configuration with the source of each value",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+var (
+	configUser     bool
+	configMarkdown bool
+	configJSON     bool
+)
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+	rootCmd.AddCommand(configCmd)
+	setConfigFlags(configSetCmd, configListCmd)
+}
+
+func setConfigFlags(setCmd, listCmd *cobra.Command) {
+	setCmd.Flags().BoolVar(&configUser, "user", false, "Write to the user config file instead of the repository one")
+	listCmd.Flags().BoolVarP(&configMarkdown, "markdown", "m", false, "print markdown table")
+	listCmd.Flags().BoolVarP(&configJSON, "json", "j", false, "Print JSON output")
+}
+
+// configFileName is the name of the repository and user config files.
+const configFileName = "config.yaml"
+
+// configOption is a configuration key with its environment variable, if any, and default value.
+type configOption struct {
+	key string
+	env string
+	def any
+}
+
+var configOptions = []configOption{
+	{configFolder, envVarDir, defaultFolder},
+	{configAutoCommit, envVarAutoCommit, defaultAutoCommit},
+	{configAutoDone, envVarAutoDone, defaultAutoDone},
+	{configLogLevel, envVarLogLevel, defaultLogLevel},
+	{configLogFormat, envVarLogFormat, defaultLogFormat},
+	{configLogFile, envVarLogFile, defaultLogFile},
+	{configStatuses, "", defaultStatuses},
+	{configTransitions, "", defaultTransitions},
+	{configFields, "", defaultFields},
+}
+
+// Where a configuration value comes from.
+const (
+	sourceFlag    = "flag"
+	sourceEnv     = "env"
+	sourceRepo    = "repo"
+	sourceUser    = "user"
+	sourceDefault = "default"
+)
+
+// configFiles holds the config files read when the command started.
+type configFiles struct {
+	userPath, repoPath string
+	user, repo         map[string]any
+}
+
+var loadedConfig configFiles
+
+// loadConfigFiles merges the user config file, then the repository config file
+// found in the tasks directory, below the flags and environment variables.
+// A key of the repository config replaces the same key of the user config as
+// a whole: statuses, transitions and fields are not merged.
+func loadConfigFiles(fsys afero.Fs) {
+	if dir, err := os.UserConfigDir(); err == nil {
+		loadedConfig.userPath = filepath.Join(dir, "backlog", configFileName)
+	}
+	user, err := readConfigFile(fsys, loadedConfig.userPath)
+	if err != nil {
+		logging.Error("user config", "error", err)
+	}
+	loadedConfig.user = user
+	checkErr(viper.MergeConfigMap(user))
+
+	tasksDir, err := paths.ResolveTasksDir(fsys, viper.GetString(configFolder))
+	if err != nil {
+		logging.Error("tasks directory", "error", err)
+		return
+	}
+	loadedConfig.repoPath = filepath.Join(tasksDir, configFileName)
+	repo, err := readConfigFile(fsys, loadedConfig.repoPath)
+	if err != nil {
+		logging.Error("repository config", "error", err)
+	}
+	if _, ok := repo[configFolder]; ok {
+		logging.Warn("ignoring the folder key of the repository config", "path", loadedConfig.repoPath)
+		delete(repo, configFolder)
+	}
+	loadedConfig.repo = repo
+
+	// The user config locates the tasks directory, it is then replaced by
+	// both files since merging a map in viper merges its keys.
+	config := maps.Clone(user)
+	maps.Copy(config, repo)
+	data, err := yaml.Marshal(config)
+	checkErr(err)
+	viper.SetConfigType("yaml")
+	checkErr(viper.ReadConfig(bytes.NewReader(data)))
+}
+
+// readConfigFile returns the known keys of a config file, none if it does not exist.
+func readConfigFile(fsys afero.Fs, path string) (map[string]any, error) {
+	config := map[string]any{}
+	if path == "" {
+		return config, nil
+	}
+	data, err := afero.ReadFile(fsys, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return map[string]any{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+	for key := range config {
+		if _, ok := lookupConfigOption(key); !ok {
+			logging.Warn("unknown config key", "key", key, "path", path)
+			delete(config, key)
+		}
+	}
+	return config, nil
+}
+
+// writeConfigValue sets a key in a config file, keeping its other keys.
+func writeConfigValue(fsys afero.Fs, path, key string, value any) error {
+	config := map[string]any{}
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+	config[key] = value
+	data, err = yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", path, err)
+	}
+	if err := fsys.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create directory of %s: %w", path, err)
+	}
+	if err := core.WriteFileAtomic(fsys, path, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+func lookupConfigOption(key string) (configOption, bool) {
+	i := slices.IndexFunc(configOptions, func(o configOption) bool { return o.key == key })
+	if i < 0 {
+		return configOption{}, false
+	}
+	return configOptions[i], true
+}
+
+// parseConfigValue converts the value of a key to the type of its default value.
+func parseConfigValue(opt configOption, value string) (any, error) {
+	switch opt.def.(type) {
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects true or false, got %q", opt.key, value)
+		}
+		return b, nil
+	case []string:
+		return strings.Split(value, ","), nil
+	case map[string][]string, map[string]any:
+		return nil, fmt.Errorf("%s cannot be set from the command line, edit the config file", opt.key)
+	default:
+		return value, nil
+	}
+}
+
+// configSource returns where the effective value of a key comes from.
+func configSource(cmd *cobra.Command, opt configOption) string {
+	if f := cmd.Flags().Lookup(opt.key); f != nil && f.Changed {
+		return sourceFlag
+	}
+	if opt.env != "" && os.Getenv(opt.env) != "" {
+		return sourceEnv
+	}
+	if _, ok := loadedConfig.repo[opt.key]; ok {
+		return sourceRepo
+	}
+	if _, ok := loadedConfig.user[opt.key]; ok {
+		return sourceUser
+	}
+	return sourceDefault
+}
+
+// configEntry is the effective value of a configuration key.
+type configEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	// Path is the config file the value comes from, if any.
+	Path string `json:"path,omitempty"`
+}
+
+func effectiveConfig(cmd *cobra.Command, opt configOption) configEntry {
+	entry := configEntry{Key: opt.key, Value: configValue(opt.key), Source: configSource(cmd, opt)}
+	switch entry.Source {
+	case sourceRepo:
+		entry.Path = loadedConfig.repoPath
+	case sourceUser:
+		entry.Path = loadedConfig.userPath
+	}
+	return entry
+}
+
+// configValue formats the effective value of a key, lists and maps as JSON.
+func configValue(key string) string {
+	switch v := viper.Get(key).(type) {
+	case string, bool, nil:
+		return viper.GetString(key)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+func unknownKeyError(key string) error {
+	keys := make([]string, len(configOptions))
+	for i, o := range configOptions {
+		keys[i] = o.key
+	}
+	return fmt.Errorf("unknown config key %q (want one of %v)", key, keys)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	opt, ok := lookupConfigOption(args[0])
+	if !ok {
+		return unknownKeyError(args[0])
+	}
+	if _, err := fmt.Fprintln(cmd.OutOrStdout(), configValue(opt.key)); err != nil {
+		return fmt.Errorf("writer: %v", err)
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	opt, ok := lookupConfigOption(args[0])
+	if !ok {
+		return unknownKeyError(args[0])
+	}
+	value, err := parseConfigValue(opt, args[1])
+	if err != nil {
+		return err
+	}
+	if opt.key == configStatuses {
+		if _, err := core.NewWorkflow(value.([]string), viper.GetStringMapStringSlice(configTransitions)); err != nil {
+			return err
+		}
+	}
+	path := loadedConfig.repoPath
+	if configUser {
+		path = loadedConfig.userPath
+	} else if opt.key == configFolder {
+		return fmt.Errorf("%s cannot be set in the repository config, use --user, the --%s flag or %s", configFolder, configFolder, envVarDir)
+	}
+	if path == "" {
+		return fmt.Errorf("no config file to write %s to", opt.key)
+	}
+	if err := writeConfigValue(afero.NewOsFs(), path, opt.key, value); err != nil {
+		return fmt.Errorf("failed to set %s: %w", opt.key, err)
+	}
+	if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Set %s to %v in %s\n", opt.key, value, path); err != nil {
+		return fmt.Errorf("writer: %v", err)
+	}
+	return nil
+}
+
+func runConfigList(cmd *cobra.Command, args []string) error {
+	entries := make([]configEntry, len(configOptions))
+	for i, opt := range configOptions {
+		entries[i] = effectiveConfig(cmd, opt)
+	}
+
+	w := cmd.OutOrStdout()
+	if configJSON {
+		if err := json.NewEncoder(w).Encode(entries); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+	table := tableWriter(w, configMarkdown)
+	table.Header([]string{"Key", "Value", "Source", "Path"})
+	for _, e := range entries {
+		if err := table.Append([]string{e.Key, e.Value, e.Source, e.Path}); err != nil {
+			return fmt.Errorf("failed to append table row for %s: %w", e.Key, err)
+		}
+	}
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
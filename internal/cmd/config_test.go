@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+func Test_configFile(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	path := ".backlog/config.yaml"
+
+	config, err := readConfigFile(fs, path)
+	is.NoErr(err)
+	is.Equal(len(config), 0) // missing file
+
+	value, err := parseConfigValue(configOptions[1], "true")
+	is.NoErr(err)
+	is.NoErr(writeConfigValue(fs, path, configAutoCommit, value))
+	is.NoErr(writeConfigValue(fs, path, configLogLevel, "debug"))
+	_, err = parseConfigValue(configOptions[1], "maybe")
+	is.True(err != nil) // not a boolean
+
+	is.NoErr(afero.WriteFile(fs, "other.yaml", []byte("auto-commit: true\nunknown: 1\n"), 0o644))
+	config, err = readConfigFile(fs, "other.yaml")
+	is.NoErr(err)
+	is.Equal(config, map[string]any{configAutoCommit: true}) // unknown keys are dropped
+
+	config, err = readConfigFile(fs, path)
+	is.NoErr(err)
+	is.Equal(config, map[string]any{configAutoCommit: true, configLogLevel: "debug"})
+
+	is.NoErr(afero.WriteFile(fs, "bad.yaml", []byte("auto-commit: [\n"), 0o644))
+	_, err = readConfigFile(fs, "bad.yaml")
+	is.True(err != nil) // invalid YAML
+}
+
+func Test_loadConfigFiles(t *testing.T) {
+	is := is.New(t)
+	t.Setenv("XDG_CONFIG_HOME", "/home/user/.config")
+	t.Cleanup(func() {
+		checkErr(viper.ReadConfig(bytes.NewReader(nil)))
+		loadedConfig = configFiles{}
+	})
+	viper.SetDefault(configFolder, defaultFolder)
+	fs := afero.NewMemMapFs()
+	is.NoErr(afero.WriteFile(fs, "/home/user/.config/backlog/config.yaml", []byte(`
+log-level: debug
+fields:
+  sprint: {type: int}
+  customer: {type: list}
+`), 0o644))
+	is.NoErr(afero.WriteFile(fs, ".backlog/config.yaml", []byte(`
+fields:
+  component: {type: enum, values: [api, cli]}
+`), 0o644))
+
+	loadConfigFiles(fs)
+	is.Equal(viper.GetString(configLogLevel), "debug") // from the user config
+	fields := viper.GetStringMap(configFields)
+	is.Equal(len(fields), 1) // the repository fields replace the user fields
+	_, ok := fields["component"]
+	is.True(ok)
+}
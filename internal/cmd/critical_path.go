@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/veggiemonk/backlog/internal/core"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var criticalPathExample = `
+backlog critical-path               # Longest dependency chain and the tasks blocking the most work
+backlog critical-path --limit 5     # Only the 5 tasks blocking the most work
+backlog critical-path --markdown    # Print markdown tables
+backlog critical-path --json        # Print JSON output
+`
+
+var criticalPathCmd = &cobra.Command{
+	Use:   "critical-path",
+	Short: "Show the longest dependency chain and the tasks blocking the most work",
+	Long: `Analyzes the dependencies between the unfinished tasks.
+The critical path is the longest chain of unfinished tasks depending on each other, starting with the task to do first.
+The blocking tasks are ordered by the number of unfinished tasks depending on them, directly or transitively.
+Tasks that are done, cancelled, archived or in a final status of the workflow do not block anything.`,
+	Example: criticalPathExample,
+	Args:    cobra.NoArgs,
+	RunE:    runCriticalPath,
+}
+
+var (
+	criticalPathLimit    int
+	criticalPathMarkdown bool
+	criticalPathJSON     bool
+)
+
+func init() {
+	rootCmd.AddCommand(criticalPathCmd)
+	setCriticalPathFlags(criticalPathCmd)
+}
+
+func setCriticalPathFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&criticalPathLimit, "limit", 0, "Maximum number of blocking tasks to show (0 means no limit)")
+	cmd.Flags().BoolVarP(&criticalPathMarkdown, "markdown", "m", false, "print markdown table")
+	cmd.Flags().BoolVarP(&criticalPathJSON, "json", "j", false, "Print JSON output")
+}
+
+func runCriticalPath(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	result, err := store.CriticalPath(core.CriticalPathParams{Limit: criticalPathLimit})
+	if err != nil {
+		return fmt.Errorf("failed to compute the critical path: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if criticalPathJSON {
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+	if len(result.Path) == 0 {
+		if _, err := fmt.Fprintln(w, "No unfinished tasks."); err != nil {
+			return fmt.Errorf("writer: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "Critical path (%d tasks):\n", len(result.Path)); err != nil {
+		return fmt.Errorf("writer: %v", err)
+	}
+	if err := renderBlockingTasks(w, result.Path, true); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "\nBlocking tasks:"); err != nil {
+		return fmt.Errorf("writer: %v", err)
+	}
+	if len(result.Blockers) == 0 {
+		if _, err := fmt.Fprintln(w, "No task is blocking another one."); err != nil {
+			return fmt.Errorf("writer: %v", err)
+		}
+		return nil
+	}
+	return renderBlockingTasks(w, result.Blockers, false)
+}
+
+// renderBlockingTasks renders the tasks with the number of tasks they block,
+// numbering them when they form a path.
+func renderBlockingTasks(w io.Writer, tasks []core.BlockingTask, numbered bool) error {
+	header := []string{"ID", "Status", "Priority", "Title", "Blocks"}
+	if numbered {
+		header = append([]string{"#"}, header...)
+	}
+	table := tableWriter(w, criticalPathMarkdown)
+	table.Header(header)
+	for i, bt := range tasks {
+		row := []string{
+			bt.Task.ID.String(),
+			string(bt.Task.Status),
+			bt.Task.Priority.String(),
+			bt.Task.Title,
+			strconv.Itoa(bt.Blocks),
+		}
+		if numbered {
+			row = append([]string{strconv.Itoa(i + 1)}, row...)
+		}
+		if err := table.Append(row); err != nil {
+			return fmt.Errorf("failed to append table row for task %s: %w", bt.Task.ID, err)
+		}
+	}
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
@@ -32,6 +32,10 @@ Conflict types detected:
 - Duplicate IDs (same ID in multiple files)
 - Orphaned children (tasks with non-existent parents)
 - Invalid hierarchy (parent-child ID mismatch)
+- Archived collisions (archived task sharing its ID with another task)
+- Dependency cycles (tasks depending on each other, directly or not)
+- Dangling dependencies (dependencies on archived, deleted or missing tasks)
+- Unknown statuses (statuses that are not part of the configured workflow)
 `
 
 var doctorExamples = `
@@ -87,6 +91,11 @@ func detectConflicts(w io.Writer, fs afero.Fs, tasksDir string) error {
 	}
 
 	detector := core.NewConflictDetector(fs, tasksDir)
+	workflow, err := workflowFromConfig()
+	if err != nil {
+		return fmt.Errorf("invalid workflow: %w", err)
+	}
+	detector.SetWorkflow(workflow)
 
 	// Detect conflicts
 	conflicts, err := detector.DetectConflicts()
@@ -138,6 +147,38 @@ func detectConflicts(w io.Writer, fs afero.Fs, tasksDir string) error {
 		logging.Warn("invalid hierarchy", slog.Int("found", summary.InvalidHierarchy), slog.Any("references", conflicts))
 	}
 
+	if summary.ArchivedCollisions > 0 {
+		conflicts := map[string][]string{}
+		for _, conflict := range summary.ConflictsByType[core.ConflictTypeArchivedCollision] {
+			conflicts[conflict.ConflictID.String()] = conflict.Files
+		}
+		logging.Warn("archived collisions", slog.Int("found", summary.ArchivedCollisions), slog.Any("details", conflicts))
+	}
+
+	if summary.DependencyCycles > 0 {
+		conflicts := []string{}
+		for _, conflict := range summary.ConflictsByType[core.ConflictTypeDependencyCycle] {
+			conflicts = append(conflicts, conflict.Description)
+		}
+		logging.Warn("dependency cycles", slog.Int("found", summary.DependencyCycles), slog.Any("cycles", conflicts))
+	}
+
+	if summary.DanglingDependencies > 0 {
+		conflicts := []string{}
+		for _, conflict := range summary.ConflictsByType[core.ConflictTypeDanglingDependency] {
+			conflicts = append(conflicts, conflict.Description)
+		}
+		logging.Warn("dangling dependencies", slog.Int("found", summary.DanglingDependencies), slog.Any("references", conflicts))
+	}
+
+	if summary.UnknownStatuses > 0 {
+		conflicts := []string{}
+		for _, conflict := range summary.ConflictsByType[core.ConflictTypeUnknownStatus] {
+			conflicts = append(conflicts, conflict.Description)
+		}
+		logging.Warn("unknown statuses", slog.Int("found", summary.UnknownStatuses), slog.Any("tasks", conflicts))
+	}
+
 	logging.Info("Run 'backlog doctor --fix' to fix these conflicts.")
 	return nil
 }
@@ -151,6 +192,11 @@ func resolveConflicts(fs afero.Fs, tasksDir string) error {
 	}
 
 	detector := core.NewConflictDetector(fs, tasksDir)
+	workflow, err := workflowFromConfig()
+	if err != nil {
+		return fmt.Errorf("invalid workflow: %w", err)
+	}
+	detector.SetWorkflow(workflow)
 
 	// Detect conflicts first
 	conflicts, err := detector.DetectConflicts()
@@ -164,7 +210,7 @@ func resolveConflicts(fs afero.Fs, tasksDir string) error {
 	}
 
 	// Create resolver
-	store := core.NewFileTaskStore(fs, tasksDir)
+	store := core.NewFileTaskStore(fs, tasksDir, core.WithWorkflow(workflow))
 	resolver := core.NewConflictResolver(detector, store)
 
 	// Parse strategy
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/veggiemonk/backlog/internal/core"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var graphExample = `
+backlog graph                             # Mermaid flowchart of all the tasks
+backlog graph --parent 12                 # Only task 12 and its subtasks, with the tasks they depend on
+backlog graph --format dot | dot -Tsvg -o backlog.svg   # Render with Graphviz
+backlog graph --include-archived          # Also draw archived tasks
+`
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export the task graph to DOT or Mermaid",
+	Long: `Prints the parent and dependency relationships between tasks as a Graphviz DOT graph
+or a Mermaid flowchart, to paste into pull requests and documentation.
+Dependency edges point from a task to the tasks depending on it, subtask edges are dashed.
+Nodes are colored by status and their border is styled by priority.
+Tasks outside of the --parent subtree that it depends on are drawn with a dashed border.`,
+	Example: graphExample,
+	Args:    cobra.NoArgs,
+	RunE:    runGraph,
+}
+
+var (
+	graphParent          string
+	graphFormat          string
+	graphIncludeArchived bool
+)
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	setGraphFlags(graphCmd)
+}
+
+func setGraphFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&graphParent, "parent", "p", "", "Only draw this task and its subtasks")
+	cmd.Flags().StringVarP(&graphFormat, "format", "f", string(core.GraphFormatMermaid), "Output format (mermaid, dot)")
+	cmd.Flags().BoolVar(&graphIncludeArchived, "include-archived", false, "Include archived tasks")
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	listResult, err := store.List(core.ListTasksParams{Archived: archivedMode(graphIncludeArchived, false)})
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	params := core.GraphParams{Root: graphParent, Format: core.GraphFormat(graphFormat)}
+	if err := core.WriteGraph(cmd.OutOrStdout(), listResult.Tasks, params); err != nil {
+		return fmt.Errorf("graph: %w", err)
+	}
+	return nil
+}
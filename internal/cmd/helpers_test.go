@@ -114,8 +114,20 @@ func createTestTasks(t *testing.T, store mcpserver.TaskStore) {
 	if err = store.Update(&t9, core.EditTaskParams{NewStatus: ptr("in-progress")}); err != nil {
 		t.Fatalf("failed to update task: %v", err)
 	}
+
+	t10, err := store.Create(core.CreateTaskParams{
+		Title:       "Archived Task",
+		Description: "archived description.",
+	})
+	if err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+	if _, err = store.Archive(t10.ID); err != nil {
+		t.Fatalf("failed to archive task: %v", err)
+	}
 }
 
+// countTask is the number of active tasks, the archived task is not counted.
 const countTask = 9
 
 func ptr[T any](v T) *T {
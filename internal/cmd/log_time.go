@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var logTimeExample = `
+backlog log-time T05 1h30m                          # Log one hour and a half spent on task T05
+backlog log-time T05 45m --note "Reviewed the PR"   # Log time with what it was spent on
+backlog edit T05 --spent 2h                         # Correct the total time spent instead
+`
+
+var logTimeCmd = &cobra.Command{
+	Use:   "log-time <id> <duration>",
+	Short: "Log time spent on a task",
+	Long: `Adds a duration such as 45m or 1h30m to the time spent on a task, recorded in its history.
+The time spent and the estimates are summed over the subtasks in 'backlog view', 'backlog list --json' and 'backlog report effort'.`,
+	Example: logTimeExample,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runLogTime,
+}
+
+var logTimeNote string
+
+func init() {
+	rootCmd.AddCommand(logTimeCmd)
+	logTimeCmd.Flags().StringVarP(&logTimeNote, "note", "n", "", "What the time was spent on")
+}
+
+func runLogTime(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	task, err := store.LogTime(core.LogTimeParams{ID: args[0], Duration: args[1], Note: logTimeNote})
+	if err != nil {
+		return fmt.Errorf("failed to log time on task %q: %w", args[0], err)
+	}
+
+	logging.Info("time logged successfully", "task_id", task.ID, "spent", task.Spent)
+
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	commitMsg := fmt.Sprintf("feat(task): log time on %s - \"%s\"", task.ID, task.Title)
+	if err := commit.Add(store.Path(task), "", commitMsg); err != nil {
+		logging.Warn("auto-commit failed", "task_id", task.ID, "error", err)
+	}
+	return nil
+}
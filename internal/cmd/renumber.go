@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var renumberExample = `
+backlog renumber                            # compact the top-level task IDs
+backlog renumber --parent 4                 # compact the subtasks of task 4: 4.1, 4.4, 4.9 become 4.1, 4.2, 4.3
+backlog renumber --parent 4 --by-priority   # number the subtasks of task 4 by decreasing priority
+backlog renumber --parent 4 --order 4.9,4.1 # 4.9 becomes 4.1, 4.1 becomes 4.2, the others follow
+backlog renumber --parent 4 --dry-run       # show what would be renumbered without making changes
+`
+
+var renumberCmd = &cobra.Command{
+	Use:   "renumber",
+	Short: "Renumber sibling tasks",
+	Long: `Gives consecutive IDs to the subtasks of a task, or to the top-level tasks, closing the gaps
+left by deleted and archived tasks. Since the ID is the only ordering of tasks, the siblings can also be
+reordered by priority or in a given order.
+Active subtasks follow their parent to its new ID and the dependencies on the renumbered tasks are updated. Archived and deleted tasks keep their ID.
+IDs of archived and deleted tasks are never reused, they are skipped.`,
+	Example: renumberExample,
+	Args:    cobra.NoArgs,
+	RunE:    runRenumber,
+}
+
+var (
+	renumberParent     string
+	renumberByPriority bool
+	renumberOrder      []string
+	renumberDryRun     bool
+)
+
+func init() {
+	rootCmd.AddCommand(renumberCmd)
+	setRenumberFlags(renumberCmd)
+}
+
+func setRenumberFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&renumberParent, "parent", "p", "", "Renumber the subtasks of this task instead of the top-level tasks")
+	cmd.Flags().BoolVar(&renumberByPriority, "by-priority", false, "Order the tasks by decreasing priority")
+	cmd.Flags().StringSliceVar(&renumberOrder, "order", nil, "Comma-separated IDs in their new order, the other tasks follow")
+	cmd.Flags().BoolVar(&renumberDryRun, "dry-run", false, "Show what would be changed without making changes")
+	cmd.MarkFlagsMutuallyExclusive("by-priority", "order")
+}
+
+func runRenumber(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	result, err := store.Renumber(core.RenumberParams{
+		Parent:     renumberParent,
+		ByPriority: renumberByPriority,
+		Order:      renumberOrder,
+		DryRun:     renumberDryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("renumber: %v", err)
+	}
+
+	if len(result.Changes) == 0 {
+		logging.Info("no tasks to renumber")
+		return nil
+	}
+	for i, c := range result.Changes {
+		logging.Info(fmt.Sprintf("%d. Renumber %s to %s", i+1, c.OldID.Name(), c.NewID.Name()))
+		logging.Info(fmt.Sprintf("   File: %s -> %s", c.OldPath, c.Path))
+	}
+	if result.DryRun {
+		logging.Info("DRY RUN - No changes were made")
+		return nil
+	}
+	logging.Info("success", "tasks renumbered", len(result.Changes))
+
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	// Auto-commit the change if enabled
+	commitMsg := fmt.Sprintf("chore(task): renumber %d tasks", len(result.Changes))
+	if err := commit.AddAll(commitMsg, result.Paths(store.Path)...); err != nil {
+		logging.Warn("auto-commit failed", "error", err)
+	}
+	return nil
+}
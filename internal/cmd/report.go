@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/veggiemonk/backlog/internal/core"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var reportExample = `
+backlog report effort                   # Estimates and time spent by parent task
+backlog report effort --by label        # By label
+backlog report effort --by assignee -m  # By assignee, as a markdown table
+backlog report effort --json            # Print JSON output
+`
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	Short:   "Summarize the tasks",
+	Long:    `Prints summaries of the active tasks.`,
+	Example: reportExample,
+}
+
+var reportEffortCmd = &cobra.Command{
+	Use:   "effort",
+	Short: "Compare the estimates with the time spent",
+	Long: `Sums the estimates, in points, and the time spent of the active tasks, grouped by parent, label or assignee.
+Grouping by parent, each task is counted under its direct parent. Grouping by label or assignee, a task is counted
+under each of its labels or assignees, and the total counts it once.`,
+	Example: reportExample,
+	Args:    cobra.NoArgs,
+	RunE:    runReportEffort,
+}
+
+var (
+	reportBy       string
+	reportMarkdown bool
+	reportJSON     bool
+)
+
+func init() {
+	reportCmd.AddCommand(reportEffortCmd)
+	rootCmd.AddCommand(reportCmd)
+	reportEffortCmd.Flags().StringVar(&reportBy, "by", core.EffortByParent, "Group the tasks by parent, label or assignee")
+	reportEffortCmd.Flags().BoolVarP(&reportMarkdown, "markdown", "m", false, "print markdown table")
+	reportEffortCmd.Flags().BoolVarP(&reportJSON, "json", "j", false, "Print JSON output")
+}
+
+func runReportEffort(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	report, err := store.EffortReport(core.EffortReportParams{By: reportBy})
+	if err != nil {
+		return fmt.Errorf("failed to compute the effort report: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if reportJSON {
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+	if report.Total.Tasks == 0 {
+		if _, err := fmt.Fprintln(w, "No tasks found."); err != nil {
+			return fmt.Errorf("writer: %v", err)
+		}
+		return nil
+	}
+	return renderEffortReport(w, report)
+}
+
+// renderEffortReport renders the groups of the report followed by the total.
+func renderEffortReport(w io.Writer, report core.EffortReport) error {
+	none := map[string]string{
+		core.EffortByParent:   "(no parent)",
+		core.EffortByLabel:    "(no label)",
+		core.EffortByAssignee: "(unassigned)",
+	}
+	table := tableWriter(w, reportMarkdown)
+	table.Header([]string{report.By, "Tasks", "Done", "Estimate", "Spent"})
+	row := func(g core.EffortGroup, name string) []string {
+		return []string{
+			name,
+			strconv.Itoa(g.Tasks),
+			strconv.Itoa(g.Done),
+			strconv.FormatFloat(g.Estimate, 'f', -1, 64),
+			g.Spent.String(),
+		}
+	}
+	for _, g := range report.Groups {
+		name := g.Key
+		switch {
+		case name == "":
+			name = none[report.By]
+		case g.Title != "":
+			name = fmt.Sprintf("%s %s", g.Key, g.Title)
+		}
+		if err := table.Append(row(g, name)); err != nil {
+			return fmt.Errorf("failed to append table row for %s: %w", name, err)
+		}
+	}
+	if err := table.Append(row(report.Total, "Total")); err != nil {
+		return fmt.Errorf("failed to append total row: %w", err)
+	}
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
@@ -48,6 +48,7 @@ const (
 	envVarLogLevel   = envPrefix + "_LOG_LEVEL"
 	envVarLogFormat  = envPrefix + "_LOG_FORMAT"
 	envVarAutoCommit = envPrefix + "_AUTO_COMMIT"
+	envVarAutoDone   = envPrefix + "_AUTO_DONE_PARENTS"
 
 	// folder
 	configFolder  = "folder"
@@ -58,6 +59,10 @@ const (
 	configAutoCommit  = "auto-commit"
 	defaultAutoCommit = false
 
+	// progress
+	configAutoDone  = "auto-done-parents"
+	defaultAutoDone = false
+
 	// logging
 	configLogLevel   = "log-level"
 	defaultLogLevel  = "info"
@@ -65,6 +70,19 @@ const (
 	defaultLogFormat = "text"
 	configLogFile    = "log-file"
 	defaultLogFile   = ""
+
+	// workflow, only in config files
+	configStatuses    = "statuses"
+	configTransitions = "transitions"
+
+	// custom fields, only in config files
+	configFields = "fields"
+)
+
+var (
+	defaultStatuses    = core.DefaultWorkflow().Names()
+	defaultTransitions = map[string][]string{}
+	defaultFields      = map[string]any{}
 )
 
 func preRun(cmd *cobra.Command, args []string) {
@@ -78,8 +96,9 @@ func preRun(cmd *cobra.Command, args []string) {
 	// Use Viper to get the tasks directory
 	tasksDir := viper.GetString(configFolder)
 	autoCommit := viper.GetBool(configAutoCommit)
+	autoDone := viper.GetBool(configAutoDone)
 
-	logging.Debug("resolve env var", configFolder, tasksDir, configAutoCommit, autoCommit)
+	logging.Debug("resolve env var", configFolder, tasksDir, configAutoCommit, autoCommit, configAutoDone, autoDone)
 	fs := afero.NewOsFs()
 	var err error
 	tasksDir, err = paths.ResolveTasksDir(fs, tasksDir)
@@ -87,7 +106,17 @@ func preRun(cmd *cobra.Command, args []string) {
 		logging.Error("tasks directory", "error", err)
 	}
 	logging.Debug("resolve tasks directory", configFolder, tasksDir)
-	var store mcpserver.TaskStore = core.NewFileTaskStore(fs, tasksDir)
+	workflow, err := workflowFromConfig()
+	if err != nil {
+		logging.Error("workflow", "error", err)
+		workflow = core.DefaultWorkflow()
+	}
+	fields, err := fieldsFromConfig()
+	if err != nil {
+		logging.Error("custom fields", "error", err)
+	}
+	var store mcpserver.TaskStore = core.NewFileTaskStore(fs, tasksDir,
+		core.WithAutoDoneParents(autoDone), core.WithWorkflow(workflow), core.WithFields(fields))
 	cmd.SetContext(context.WithValue(cmd.Context(), ctxKeyStore, store))
 }
 
@@ -99,16 +128,34 @@ func initConfig() {
 	// Set default values
 	viper.SetDefault(configFolder, defaultFolder)
 	viper.SetDefault(configAutoCommit, defaultAutoCommit)
+	viper.SetDefault(configAutoDone, defaultAutoDone)
 	viper.SetDefault(configLogLevel, defaultLogLevel)
 	viper.SetDefault(configLogFormat, defaultLogFormat)
 	viper.SetDefault(configLogFile, defaultLogFile)
+	viper.SetDefault(configStatuses, defaultStatuses)
+	viper.SetDefault(configTransitions, defaultTransitions)
+	viper.SetDefault(configFields, defaultFields)
 
 	// Bind environment variables with their keys
 	checkErr(viper.BindEnv(configFolder, envVarDir))
 	checkErr(viper.BindEnv(configAutoCommit, envVarAutoCommit))
+	checkErr(viper.BindEnv(configAutoDone, envVarAutoDone))
 	checkErr(viper.BindEnv(configLogLevel, envVarLogLevel))
 	checkErr(viper.BindEnv(configLogFormat, envVarLogFormat))
 	checkErr(viper.BindEnv(configLogFile, envVarLogFile))
+
+	// Config files, below flags and environment variables
+	loadConfigFiles(afero.NewOsFs())
+}
+
+// workflowFromConfig returns the statuses and transitions of the config files.
+func workflowFromConfig() (core.Workflow, error) {
+	return core.NewWorkflow(viper.GetStringSlice(configStatuses), viper.GetStringMapStringSlice(configTransitions))
+}
+
+// fieldsFromConfig returns the custom fields declared in the config files.
+func fieldsFromConfig() ([]core.FieldDef, error) {
+	return core.ParseFieldDefs(viper.GetStringMap(configFields))
 }
 
 func checkErr(err error) {
@@ -120,6 +167,7 @@ func checkErr(err error) {
 func setRootPersistentFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(configFolder, defaultFolder, "Directory for backlog tasks")
 	cmd.PersistentFlags().Bool(configAutoCommit, defaultAutoCommit, "Auto-committing changes to git repository")
+	cmd.PersistentFlags().Bool(configAutoDone, defaultAutoDone, "Mark parent tasks done when all their subtasks and acceptance criteria are complete")
 	cmd.PersistentFlags().String(configLogLevel, defaultLogLevel, "Log level (debug, info, warn, error)")
 	cmd.PersistentFlags().String(configLogFormat, defaultLogFormat, "Log format (json, text)")
 	cmd.PersistentFlags().String(configLogFile, defaultLogFile, "Log file path (defaults to stderr)")
@@ -127,6 +175,7 @@ func setRootPersistentFlags(cmd *cobra.Command) {
 	// Bind flags to viper
 	checkErr(viper.BindPFlag(configFolder, cmd.PersistentFlags().Lookup(configFolder)))
 	checkErr(viper.BindPFlag(configAutoCommit, cmd.PersistentFlags().Lookup(configAutoCommit)))
+	checkErr(viper.BindPFlag(configAutoDone, cmd.PersistentFlags().Lookup(configAutoDone)))
 	checkErr(viper.BindPFlag(configLogLevel, cmd.PersistentFlags().Lookup(configLogLevel)))
 	checkErr(viper.BindPFlag(configLogFormat, cmd.PersistentFlags().Lookup(configLogFormat)))
 	checkErr(viper.BindPFlag(configLogFile, cmd.PersistentFlags().Lookup(configLogFile)))
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/veggiemonk/backlog/internal/core"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var searchExample = `
+backlog search login                        # Tasks about login, the most relevant first
+backlog search "reset password" --limit 5   # The 5 most relevant tasks
+backlog search pasword                      # Typos still find the tasks about passwords
+backlog search login --include-archived     # Also search archived tasks
+backlog search login --json                 # Print JSON output
+`
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tasks ranked by relevance",
+	Long: `Searches the title, acceptance criteria, description, implementation plan and notes of the tasks.
+Results are ranked by relevance, matches in the title weighing the most, and show a snippet with the matched words highlighted.
+Words match exactly, as a prefix, or with a typo.`,
+	Example: searchExample,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runSearch,
+}
+
+var (
+	searchIncludeArchived bool
+	searchOnlyArchived    bool
+	searchLimit           int
+	searchOffset          int
+	searchMarkdown        bool
+	searchJSON            bool
+)
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	setSearchFlags(searchCmd)
+}
+
+func setSearchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&searchIncludeArchived, "include-archived", false, "Include archived tasks")
+	cmd.Flags().BoolVar(&searchOnlyArchived, "only-archived", false, "Search archived tasks only")
+	cmd.MarkFlagsMutuallyExclusive("include-archived", "only-archived")
+	cmd.Flags().IntVar(&searchLimit, "limit", 0, "Maximum number of results to return (0 means no limit)")
+	cmd.Flags().IntVar(&searchOffset, "offset", 0, "Number of results to skip from the beginning")
+	cmd.Flags().BoolVarP(&searchMarkdown, "markdown", "m", false, "print markdown table")
+	cmd.Flags().BoolVarP(&searchJSON, "json", "j", false, "Print JSON output")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	params := core.SearchParams{
+		Query:    strings.Join(args, " "),
+		Archived: archivedMode(searchIncludeArchived, searchOnlyArchived),
+		Limit:    searchLimit,
+		Offset:   searchOffset,
+	}
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	result, err := store.Search(params)
+	if err != nil {
+		return fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if searchJSON {
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+	if len(result.Hits) == 0 {
+		if _, err := fmt.Fprintln(w, "No tasks found."); err != nil {
+			return fmt.Errorf("writer: %v", err)
+		}
+		return nil
+	}
+	if len(result.Hits) < result.Total {
+		msg := fmt.Sprintf("Showing %d-%d of %d results", searchOffset+1, searchOffset+len(result.Hits), result.Total)
+		if _, err := fmt.Fprintln(w, msg); err != nil {
+			return fmt.Errorf("writer: %v", err)
+		}
+	}
+
+	table := tableWriter(w, searchMarkdown)
+	table.Header([]string{"ID", "Status", "Title", "Score", "Snippet"})
+	for _, hit := range result.Hits {
+		row := []string{
+			hit.Task.ID.String(),
+			string(hit.Task.Status),
+			hit.Task.Title,
+			fmt.Sprintf("%.2f", hit.Score),
+			hit.Snippet,
+		}
+		if err := table.Append(row); err != nil {
+			return fmt.Errorf("failed to append table row for task %s: %w", hit.Task.ID, err)
+		}
+	}
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
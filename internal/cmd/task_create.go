@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -75,6 +76,19 @@ backlog create "Build the new reporting feature" \
   --ac "Users can select a date range for the report." \
   --ac "The exported PDF has the correct branding and layout." \
   -p "23"	
+
+# 10. Setting Custom Fields
+# Use the --field flag, as many times as needed, to set the custom fields declared in the config (see 'backlog config').
+backlog create "Export invoices as CSV" --field component=api --field sprint=12 --field customer=acme,globex
+
+# 11. Setting Start and Due Dates
+# Use the --start and --due flags with a date (YYYY-MM-DD), today, tomorrow, a weekday or a number of days like +3d.
+backlog create "Write the release notes" --due 2025-10-01
+backlog create "Tag the release candidate" --start monday --due +5d
+
+# 12. Estimating a Task
+# Use the --estimate flag with the number of points of the task. Log the time spent with 'backlog log-time'.
+backlog create "Migrate the billing database" --estimate 5
 `
 
 var (
@@ -87,6 +101,10 @@ var (
 	ac           []string
 	plan         string
 	notes        string
+	start        string
+	due          string
+	estimate     float64
+	fields       []string
 )
 
 func init() {
@@ -101,9 +119,17 @@ func init() {
 	createCmd.Flags().StringSliceVar(&ac, "ac", []string{}, "Acceptance criterion (can be specified multiple times)")
 	createCmd.Flags().StringVar(&plan, "plan", "", "Implementation plan for the task")
 	createCmd.Flags().StringVar(&notes, "notes", "", "Additional notes for the task")
+	createCmd.Flags().StringVar(&start, "start", "", "Start date of the task (YYYY-MM-DD, today, friday, +3d)")
+	createCmd.Flags().StringVar(&due, "due", "", "Due date of the task (YYYY-MM-DD, today, friday, +3d)")
+	createCmd.Flags().Float64Var(&estimate, "estimate", 0, "Estimate of the task in points")
+	createCmd.Flags().StringArrayVar(&fields, "field", nil, "Custom field as key=value, lists comma-separated (can be specified multiple times)")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	fieldValues, err := parseFieldFlags(fields)
+	if err != nil {
+		return err
+	}
 	params := core.CreateTaskParams{
 		Title:        args[0],
 		Description:  description,
@@ -115,6 +141,10 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		AC:           ac,
 		Plan:         plan,
 		Notes:        notes,
+		Start:        start,
+		Due:          due,
+		Estimate:     estimate,
+		Fields:       fieldValues,
 	}
 
 	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
@@ -136,3 +166,19 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// parseFieldFlags returns the custom field values of --field flags written key=value.
+func parseFieldFlags(flags []string) (map[string]any, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]any, len(flags))
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --field %q, want key=value", flag)
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var deleteExample = `
+backlog delete 10                      # move task 10 to the trash
+backlog delete 10 --cascade            # delete task 10 and its subtasks
+backlog delete 10 --strip-references   # also remove task 10 from the dependencies of other tasks
+`
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <task-id>",
+	Short: "Delete a task",
+	Long: `Deletes a task by moving it to the trash directory.
+Tasks with subtasks are only deleted with --cascade, which deletes the subtasks as well.
+Tasks depending on the deleted tasks are reported, or updated with --strip-references.
+Deleted tasks can be restored with "backlog trash restore".`,
+	Example: deleteExample,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runDelete,
+}
+
+var (
+	deleteCascade         bool
+	deleteStripReferences bool
+)
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+	setDeleteFlags(deleteCmd)
+}
+
+func setDeleteFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&deleteCascade, "cascade", false, "Also delete the subtasks of the task")
+	cmd.Flags().BoolVar(&deleteStripReferences, "strip-references", false, "Remove the deleted tasks from the dependencies of other tasks")
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	result, err := store.Delete(core.DeleteTaskParams{
+		ID:              args[0],
+		Cascade:         deleteCascade,
+		StripReferences: deleteStripReferences,
+	})
+	if err != nil {
+		return fmt.Errorf("delete task %q: %v", args[0], err)
+	}
+
+	for _, deleted := range result.Deleted {
+		logging.Info("task deleted successfully", "task_id", deleted.Task.ID, "trash", deleted.TrashPath)
+	}
+	for _, ref := range result.References {
+		if result.Stripped {
+			logging.Info("removed dependency on deleted task", "task_id", ref.ID)
+		} else {
+			logging.Warn("task depends on a deleted task", "task_id", ref.ID, "dependencies", ref.Dependencies)
+		}
+	}
+
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	// Auto-commit the change if enabled
+	deleted := result.Deleted[0]
+	commitMsg := fmt.Sprintf("chore(task): delete %s - \"%s\"", deleted.Task.ID, deleted.Task.Title)
+	if err := commit.AddAll(commitMsg, result.Paths(store.Path)...); err != nil {
+		logging.Warn("auto-commit failed", "task_id", deleted.Task.ID, "error", err)
+	}
+
+	return nil
+}
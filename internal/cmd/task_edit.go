@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -100,6 +101,27 @@ backlog edit 42 --deps "T15"
 # You can make a task depend on multiple other tasks:
 backlog edit 42 --deps "T15,T18,T20"
 # This makes task 42 dependent on tasks T15, T18, and T20.
+
+# 15. Avoiding Overwriting Concurrent Changes
+# Pass the updated_at (or created_at if never updated) of the task as you last read it.
+# The edit fails if the task was modified since.
+backlog edit 42 -s "done" --expected-updated-at "2025-01-02T15:04:05.123456789Z"
+
+# 16. Setting Custom Fields
+# Use the --field flag to set the custom fields declared in the config, an empty value removes the field.
+backlog edit 42 --field component=ui --field sprint=
+
+# 17. Setting Start and Due Dates
+# Use the --start and --due flags with a date (YYYY-MM-DD), today, tomorrow, a weekday or a number of days like +3d.
+backlog edit 42 --start today --due friday
+# Remove the due date:
+backlog edit 42 --due ""
+
+# 18. Estimating a Task
+# Use the --estimate flag with a number of points, 0 removes the estimate.
+backlog edit 42 --estimate 3
+# Log time spent with 'backlog log-time 42 1h30m', or correct the total time spent:
+backlog edit 42 --spent 4h
 `
 
 var editCmd = &cobra.Command{
@@ -117,6 +139,10 @@ var (
 	newStatus       string
 	newPriority     string
 	newParent       string
+	newStart        string
+	newDue          string
+	newEstimate     float64
+	newSpent        string
 	addAssigned     []string
 	removeAssigned  []string
 	addLabels       []string
@@ -128,6 +154,9 @@ var (
 	checkAC         []int
 	uncheckAC       []int
 	removeAC        []int
+	setFields       []string
+	// optimistic concurrency
+	expectedUpdatedAt string
 )
 
 func init() {
@@ -141,6 +170,10 @@ func setEditFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&newStatus, "status", "s", "", "New status for the task")
 	cmd.Flags().StringVar(&newPriority, "priority", "", "New priority for the task")
 	cmd.Flags().StringVarP(&newParent, "parent", "p", "", "New parent for the task")
+	cmd.Flags().StringVar(&newStart, "start", "", "New start date for the task (YYYY-MM-DD, today, friday, +3d), empty to remove it")
+	cmd.Flags().StringVar(&newDue, "due", "", "New due date for the task (YYYY-MM-DD, today, friday, +3d), empty to remove it")
+	cmd.Flags().Float64Var(&newEstimate, "estimate", 0, "New estimate for the task in points, 0 to remove it")
+	cmd.Flags().StringVar(&newSpent, "spent", "", "New total time spent on the task (e.g. 1h30m), to correct it (see 'backlog log-time')")
 	cmd.Flags().StringSliceVarP(&addAssigned, "assigned", "a", nil, "Add assigned names for the task (can be specified multiple times)")
 	cmd.Flags().StringSliceVarP(&removeAssigned, "remove-assigned", "A", nil, "Assigned names to remove from the task (can be specified multiple times)")
 	cmd.Flags().StringSliceVarP(&addLabels, "labels", "l", nil, "Add labels for the task (can be specified multiple times)")
@@ -154,6 +187,10 @@ func setEditFlags(cmd *cobra.Command) {
 	cmd.Flags().IntSliceVar(&checkAC, "check-ac", nil, "Check an acceptance criterion by its index")
 	cmd.Flags().IntSliceVar(&uncheckAC, "uncheck-ac", nil, "Uncheck an acceptance criterion by its index")
 	cmd.Flags().IntSliceVar(&removeAC, "remove-ac", nil, "Remove an acceptance criterion by its index")
+
+	cmd.Flags().StringArrayVar(&setFields, "field", nil, "Set a custom field as key=value, lists comma-separated, empty to remove (can be specified multiple times)")
+
+	cmd.Flags().StringVar(&expectedUpdatedAt, "expected-updated-at", "", "Fail if the task was modified after this time (RFC3339, updated_at of the task as last read)")
 }
 
 func runEdit(cmd *cobra.Command, args []string) error {
@@ -175,6 +212,18 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("parent") {
 		params.NewParent = &newParent
 	}
+	if cmd.Flags().Changed("start") {
+		params.NewStart = &newStart
+	}
+	if cmd.Flags().Changed("due") {
+		params.NewDue = &newDue
+	}
+	if cmd.Flags().Changed("estimate") {
+		params.NewEstimate = &newEstimate
+	}
+	if cmd.Flags().Changed("spent") {
+		params.NewSpent = &newSpent
+	}
 	if cmd.Flags().Changed("deps") {
 		params.NewDependencies = newDependencies
 	}
@@ -200,6 +249,22 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		params.NewPlan = &newPlan
 	}
 
+	if cmd.Flags().Changed("field") {
+		values, err := parseFieldFlags(setFields)
+		if err != nil {
+			return err
+		}
+		params.SetFields = values
+	}
+
+	if cmd.Flags().Changed("expected-updated-at") {
+		expected, err := time.Parse(time.RFC3339Nano, expectedUpdatedAt)
+		if err != nil {
+			return fmt.Errorf("invalid --expected-updated-at %q: %w", expectedUpdatedAt, err)
+		}
+		params.ExpectedUpdatedAt = &expected
+	}
+
 	// AC params
 	params.AddAC = addAC
 	params.CheckAC = checkAC
@@ -214,10 +279,8 @@ func runEdit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to retrieve task %q: %w", params.ID, err)
 	}
-	// save the old path in case of a rename
-	oldFilePath := store.Path(task)
-
-	if err := store.Update(&task, params); err != nil {
+	result, err := store.Edit(&task, params)
+	if err != nil {
 		return fmt.Errorf("failed to update task %q: %w", params.ID, err)
 	}
 
@@ -230,14 +293,11 @@ func runEdit(cmd *cobra.Command, args []string) error {
 		return nil // autocommit is disabled
 	}
 
-	// paths to commit
-	currentFilePath := store.Path(task)
-	if oldFilePath == currentFilePath {
-		oldFilePath = ""
-	}
-	// autocommit the change if enabled
+	// autocommit the change if enabled, with the subtasks and dependents
+	// renumbered when the parent changed
 	commitMsg := fmt.Sprintf("feat(task): edit %s - \"%s\"", task.ID, task.Title)
-	if err := commit.Add(currentFilePath, oldFilePath, commitMsg); err != nil {
+	paths := append([]string{store.Path(task)}, result.Paths(store.Path)...)
+	if err := commit.AddAll(commitMsg, paths...); err != nil {
 		logging.Warn("auto-commit failed", "task_id", task.ID, "error", err)
 	}
 	return nil
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v6"
+	"github.com/go-git/go-git/v6/plumbing/object"
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/core"
+)
+
+func Test_runEditParentAutoCommit(t *testing.T) {
+	is := is.New(t)
+	dir := t.TempDir()
+	t.Chdir(dir)
+	viper.Set(configAutoCommit, true)
+	t.Cleanup(func() { viper.Set(configAutoCommit, defaultAutoCommit) })
+
+	repo, err := git.PlainInit(dir, false)
+	is.NoErr(err)
+	store := core.NewFileTaskStore(afero.NewOsFs(), ".backlog")
+	for _, p := range []core.CreateTaskParams{
+		{Title: "Moved"},
+		{Title: "Parent"},
+		{Title: "Subtask", Parent: "1"},
+		{Title: "Dependent", Dependencies: []string{"T01.01"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	worktree, err := repo.Worktree()
+	is.NoErr(err)
+	is.NoErr(worktree.AddGlob(".backlog"))
+	_, err = worktree.Commit("init", &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@localhost", When: time.Now()}})
+	is.NoErr(err)
+
+	testRootCmd := &cobra.Command{Use: "backlog"}
+	testRootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		cmd.SetContext(context.WithValue(cmd.Context(), ctxKeyStore, store))
+	}
+	editCmd := &cobra.Command{Use: "edit", RunE: runEdit}
+	setRootPersistentFlags(testRootCmd)
+	setEditFlags(editCmd)
+	testRootCmd.AddCommand(editCmd)
+	_, err = execute(t, testRootCmd, "edit", "1", "--parent", "2")
+	is.NoErr(err)
+
+	// the subtask and the dependent are committed along with the task
+	status, err := worktree.Status()
+	is.NoErr(err)
+	for path, s := range status {
+		if strings.HasSuffix(path, ".md") {
+			t.Errorf("%s is not committed: %c%c", path, s.Staging, s.Worktree)
+		}
+	}
+	dependent, err := store.Get("T03")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T02.01.01"})
+}
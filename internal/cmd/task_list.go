@@ -31,14 +31,39 @@ backlog list --priority "high"                  # List all high priority tasks
 # Search
 backlog list --query "refactor"                 # Search for tasks with the word "refactor" in them
 
+# Queries
+backlog list --where 'label:bug or priority>=high'                # Bugs and important tasks
+backlog list --where 'status:todo and (label:bug or priority>=high) and updated<7d'
+backlog list --where 'not status:done and created>2025-09-01'     # Unfinished tasks created since September
+backlog list --where 'title:refactor assigned:alice'              # Terms next to each other are combined with "and"
+
+# dates: RFC 3339 times, dates or ages like 7d, 2w, 12h
+backlog list --created-after 2025-09-01 --created-before 2025-10-01  # Tasks created in September
+backlog list --created-after 2w                 # Tasks created in the last two weeks
+backlog list --status done --updated-since 7d   # Tasks completed in the last week
+
+# due dates: YYYY-MM-DD, today, tomorrow, a weekday or a number of days like +3d
+backlog list --overdue                          # Unfinished tasks due before today
+backlog list --due-before +7d --sort due        # Tasks due within a week, soonest first
+backlog list --where 'due<=friday and not status:done'  # Unfinished tasks due by friday
+
+# archived tasks
+backlog list --include-archived                 # List active and archived tasks
+backlog list --only-archived                    # List archived tasks only
+backlog list --only-archived --query "login"    # Search archived tasks
+
 # dependency filters
 backlog list --has-dependency                   # List tasks that have at least one dependency
 backlog list --depended-on                      # List tasks that are depended on by other tasks
 backlog list --depended-on --status "todo"      # List all the blocking tasks.
 
+# saved views, defined in .backlog/views.yaml
+backlog list --view triage                      # List the tasks of the "triage" view, with its columns
+backlog list --view triage --assigned "alice"   # Refine the view with more filters
+
 # column visibility
-backlog list --hide-extra                       # Hide extra fields (labels, priority, assigned)
-backlog list -e                                 # Hide extra fields (labels, priority, assigned)
+backlog list --hide-extra                       # Hide extra fields (labels, priority, due, assigned, progress)
+backlog list -e                                 # Hide extra fields (labels, priority, due, assigned, progress)
 backlog list --status "todo" --hide-extra       # List "todo" tasks with minimal columns
 
 # sorting
@@ -69,7 +94,7 @@ backlog list --sort "priority" --limit 10       # List top 10 tasks by priority
 var listCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List all tasks",
-	Long:    `Lists all tasks in the backlog except archived tasks.`,
+	Long:    `Lists all tasks in the backlog except archived tasks, unless --include-archived or --only-archived is given.`,
 	Example: listExample,
 	RunE:    runList,
 }
@@ -81,9 +106,18 @@ var (
 	filterAssigned   []string
 	filterLabels     []string
 	query            string
+	where            string
+	viewName         string
+	createdAfter     string
+	createdBefore    string
+	updatedSince     string
+	dueBefore        string
+	overdue          bool
 	filterUnassigned bool
 	hasDependency    bool
 	dependedon       bool
+	includeArchived  bool
+	onlyArchived     bool
 	// sorting
 	sortFields   string
 	reverseOrder bool
@@ -110,14 +144,24 @@ func setListFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSliceVarP(&filterAssigned, "assigned", "a", nil, "Filter tasks by assigned names")
 	cmd.Flags().StringSliceVarP(&filterLabels, "labels", "l", nil, "Filter tasks by labels")
 	cmd.Flags().StringVarP(&query, "query", "q", "", "Search query to filter tasks by")
+	cmd.Flags().StringVarP(&where, "where", "w", "", "Query combining conditions on fields with and, or, not and parentheses")
+	cmd.Flags().StringVar(&viewName, "view", "", "Saved view providing the filters, sorting, limit and columns not given by flags (see 'backlog views')")
+	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Filter tasks created at or after a time (RFC 3339, YYYY-MM-DD or an age like 7d, 2w)")
+	cmd.Flags().StringVar(&createdBefore, "created-before", "", "Filter tasks created before a time (RFC 3339, YYYY-MM-DD or an age like 7d, 2w)")
+	cmd.Flags().StringVar(&updatedSince, "updated-since", "", "Filter tasks updated at or after a time (RFC 3339, YYYY-MM-DD or an age like 7d, 2w)")
+	cmd.Flags().StringVar(&dueBefore, "due-before", "", "Filter tasks due before a day (YYYY-MM-DD, today, friday, +7d)")
+	cmd.Flags().BoolVar(&overdue, "overdue", false, "Filter unfinished tasks due before today")
 	cmd.Flags().BoolVarP(&filterUnassigned, "unassigned", "u", false, "Filter tasks that have no one assigned")
 	cmd.Flags().BoolVarP(&hasDependency, "has-dependency", "c", false, "Filter tasks that have dependencies")
 	cmd.Flags().BoolVarP(&dependedon, "depended-on", "d", false, "Filter tasks that are depended on by other tasks")
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include archived tasks")
+	cmd.Flags().BoolVar(&onlyArchived, "only-archived", false, "List archived tasks only")
+	cmd.MarkFlagsMutuallyExclusive("include-archived", "only-archived")
 	// sorting
-	cmd.Flags().StringVar(&sortFields, "sort", "", "Sort tasks by comma-separated fields (id, title, status, priority, created, updated)")
+	cmd.Flags().StringVar(&sortFields, "sort", "", "Sort tasks by comma-separated fields (id, title, status, priority, start, due, created, updated)")
 	cmd.Flags().BoolVarP(&reverseOrder, "reverse", "r", false, "Reverse the order of tasks")
 	// column visibility
-	cmd.Flags().BoolVarP(&hideExtraFields, "hide-extra", "e", false, "Hide extra fields (labels, priority, assigned)")
+	cmd.Flags().BoolVarP(&hideExtraFields, "hide-extra", "e", false, "Hide extra fields (labels, priority, due, assigned, progress)")
 	// output format
 	cmd.Flags().BoolVarP(&markdownOutput, "markdown", "m", false, "print markdown table")
 	cmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Print JSON output")
@@ -135,11 +179,19 @@ func runList(cmd *cobra.Command, args []string) error {
 		Assigned:      filterAssigned,
 		Labels:        filterLabels,
 		Query:         query,
+		Where:         where,
+		View:          viewName,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		UpdatedSince:  updatedSince,
+		DueBefore:     dueBefore,
+		Overdue:       overdue,
 		Unassigned:    filterUnassigned,
 		HasDependency: hasDependency,
 		DependedOn:    dependedon,
 		Sort:          sortFieldsSlice,
 		Reverse:       reverseOrder,
+		Archived:      archivedMode(includeArchived, onlyArchived),
 		Limit:         limitFlag,
 		Offset:        offsetFlag,
 	}
@@ -151,12 +203,35 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list tasks: %w", err)
 	}
 
-	if err := renderTaskResultsWithPagination(cmd.OutOrStdout(), listResult, jsonOutput, markdownOutput, hideExtraFields, ""); err != nil {
+	columns := listColumns(hideExtraFields)
+	if viewName != "" && !cmd.Flags().Changed("hide-extra") {
+		view, err := store.View(viewName)
+		if err != nil {
+			return fmt.Errorf("failed to load view: %w", err)
+		}
+		if len(view.Columns) > 0 {
+			columns = view.Columns
+		}
+	}
+
+	if err := renderTaskResultsWithPagination(cmd.OutOrStdout(), listResult, jsonOutput, markdownOutput, columns, ""); err != nil {
 		return fmt.Errorf("failed to render task results: %w", err)
 	}
 	return nil
 }
 
+// archivedMode converts the archived flags to the mode used by the store.
+func archivedMode(include, only bool) core.ArchivedMode {
+	switch {
+	case only:
+		return core.ArchivedOnly
+	case include:
+		return core.ArchivedInclude
+	default:
+		return core.ArchivedExclude
+	}
+}
+
 // parseSortFields parses a comma-separated string of sort fields
 func parseSortFields(sortFields string) []string {
 	if sortFields == "" {
@@ -169,8 +244,16 @@ func parseSortFields(sortFields string) []string {
 	return sortFieldsSlice
 }
 
+// listColumns returns the columns of the task tables, without the extra fields if hidden.
+func listColumns(hideExtraFields bool) []string {
+	if hideExtraFields {
+		return core.ViewColumns[:4]
+	}
+	return core.ViewColumns
+}
+
 // renderTaskResultsWithPagination renders a slice of tasks with pagination info
-func renderTaskResultsWithPagination(w io.Writer, listResult core.ListResult, jsonOutput, markdownOutput, hideExtraFields bool, messagePrefix string) error {
+func renderTaskResultsWithPagination(w io.Writer, listResult core.ListResult, jsonOutput, markdownOutput bool, columns []string, messagePrefix string) error {
 	// For JSON output with pagination info
 	if jsonOutput && listResult.Pagination != nil {
 		if err := json.NewEncoder(w).Encode(listResult); err != nil {
@@ -198,11 +281,11 @@ func renderTaskResultsWithPagination(w io.Writer, listResult core.ListResult, js
 		}
 	}
 
-	return renderTaskResults(w, listResult.Tasks, jsonOutput, markdownOutput, hideExtraFields, messagePrefix)
+	return renderTaskResults(w, listResult.Tasks, jsonOutput, markdownOutput, columns, messagePrefix)
 }
 
-// renderTaskResults renders a slice of tasks using the specified output format
-func renderTaskResults(w io.Writer, tasks []core.Task, jsonOutput, markdownOutput, hideExtraFields bool, messagePrefix string) error {
+// renderTaskResults renders a slice of tasks using the specified output format and table columns
+func renderTaskResults(w io.Writer, tasks []core.Task, jsonOutput, markdownOutput bool, columns []string, messagePrefix string) error {
 	// Handle empty task list
 	if len(tasks) == 0 {
 		switch {
@@ -237,28 +320,18 @@ func renderTaskResults(w io.Writer, tasks []core.Task, jsonOutput, markdownOutpu
 		}
 	}
 
-	// Set table header based on hidden columns
-	header := []string{"ID", "Status", "Title", "Dependencies"}
-	if !hideExtraFields {
-		header = append(header, "Labels", "Priority", "Assigned")
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = columnHeaders[c]
 	}
 
 	table := tableWriter(w, markdownOutput)
 	table.Header(header)
 
 	for _, t := range tasks {
-		row := []string{
-			t.ID.String(),
-			string(t.Status),
-			t.Title,
-			strings.Join(t.Dependencies, ", "),
-		}
-		if !hideExtraFields {
-			row = append(row,
-				strings.Join(t.Labels, ", "),
-				t.Priority.String(),
-				strings.Join(t.Assigned, ", "),
-			)
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = columnValue(t, c)
 		}
 		if err := table.Append(row); err != nil {
 			return fmt.Errorf("failed to append table row for task %s: %w", t.ID, err)
@@ -272,6 +345,53 @@ func renderTaskResults(w io.Writer, tasks []core.Task, jsonOutput, markdownOutpu
 	return nil
 }
 
+// columnHeaders are the headers of the columns of core.ViewColumns.
+var columnHeaders = map[string]string{
+	"id":           "ID",
+	"status":       "Status",
+	"title":        "Title",
+	"dependencies": "Dependencies",
+	"labels":       "Labels",
+	"priority":     "Priority",
+	"due":          "Due",
+	"assigned":     "Assigned",
+	"progress":     "Progress",
+}
+
+// columnValue formats the field of the task displayed in a column.
+func columnValue(t core.Task, column string) string {
+	switch column {
+	case "id":
+		return t.ID.String()
+	case "status":
+		return string(t.Status)
+	case "title":
+		return t.Title
+	case "dependencies":
+		return strings.Join(t.Dependencies, ", ")
+	case "labels":
+		return strings.Join(t.Labels, ", ")
+	case "priority":
+		return t.Priority.String()
+	case "due":
+		return t.Due.String()
+	case "assigned":
+		return strings.Join(t.Assigned, ", ")
+	case "progress":
+		return progress(t)
+	default:
+		return ""
+	}
+}
+
+// progress formats the progress of the task, empty if it has none.
+func progress(t core.Task) string {
+	if t.Progress == nil {
+		return ""
+	}
+	return t.Progress.String()
+}
+
 func tableWriter(w io.Writer, md bool) *tablewriter.Table {
 	cfg := tablewriter.Config{
 		Header: tw.CellConfig{
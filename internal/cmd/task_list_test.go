@@ -38,3 +38,39 @@ func Test_runSearch(t *testing.T) {
 		is.Equal(listResult.Tasks[0].Title, "First Task")
 	})
 }
+
+func Test_runListArchived(t *testing.T) {
+	t.Run("archived tasks are excluded by default", func(t *testing.T) {
+		is := is.New(t)
+		output, err := exec(t, "list", runList, "--query", "archived", "-j")
+		is.NoErr(err)
+		listResult := &core.ListResult{}
+		is.NoErr(json.Unmarshal(output, listResult))
+		is.Equal(len(listResult.Tasks), 0)
+	})
+
+	t.Run("include archived", func(t *testing.T) {
+		is := is.New(t)
+		output, err := exec(t, "list", runList, "--include-archived", "-j")
+		is.NoErr(err)
+		listResult := &core.ListResult{}
+		is.NoErr(json.Unmarshal(output, listResult))
+		is.Equal(len(listResult.Tasks), countTask+1)
+	})
+
+	t.Run("only archived", func(t *testing.T) {
+		is := is.New(t)
+		output, err := exec(t, "list", runList, "--only-archived", "-j")
+		is.NoErr(err)
+		listResult := &core.ListResult{}
+		is.NoErr(json.Unmarshal(output, listResult))
+		is.Equal(len(listResult.Tasks), 1)
+		is.Equal(listResult.Tasks[0].Title, "Archived Task")
+	})
+
+	t.Run("flags are mutually exclusive", func(t *testing.T) {
+		is := is.New(t)
+		_, err := exec(t, "list", runList, "--include-archived", "--only-archived")
+		is.True(err != nil)
+	})
+}
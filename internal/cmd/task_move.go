@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var moveExample = `
+backlog move 5.2 --to 3      # task 5.2 becomes a subtask of task 3, e.g. 3.4
+backlog move 5.2 --to root   # task 5.2 becomes a top-level task, e.g. 12
+`
+
+var moveCmd = &cobra.Command{
+	Use:   "move <task-id> --to <parent-id|root>",
+	Short: "Move a task under another parent",
+	Long: `Moves a task under another parent task, or to the top level with --to root.
+The task takes the next free ID under its new parent, and its subtasks are renumbered along with it.
+The task files are renamed and the dependencies of other tasks on the moved tasks are updated.`,
+	Example: moveExample,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runMove,
+}
+
+var moveTo string
+
+func init() {
+	rootCmd.AddCommand(moveCmd)
+	setMoveFlags(moveCmd)
+}
+
+func setMoveFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&moveTo, "to", "", "ID of the new parent task, or 'root' for the top level")
+	_ = cmd.MarkFlagRequired("to")
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	result, err := store.Move(core.MoveTaskParams{ID: args[0], To: moveTo})
+	if err != nil {
+		return fmt.Errorf("move task %q: %v", args[0], err)
+	}
+
+	for _, moved := range result.Moved {
+		logging.Info("task moved successfully", "old_id", moved.OldID, "task_id", moved.Task.ID, "path", moved.Path)
+	}
+
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	// Auto-commit the change if enabled
+	moved := result.Moved[0]
+	commitMsg := fmt.Sprintf("chore(task): move %s to %s - \"%s\"", moved.OldID, moved.Task.ID, moved.Task.Title)
+	if err := commit.AddAll(commitMsg, result.Paths(store.Path)...); err != nil {
+		logging.Warn("auto-commit failed", "task_id", moved.Task.ID, "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/veggiemonk/backlog/internal/core"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var nextExample = `
+backlog next                        # List the tasks ready to be worked on, best candidates first
+backlog next --limit 1              # Show the single best task to work on
+backlog next --assigned "alice"     # Ready tasks assigned to alice
+backlog next --unassigned           # Ready tasks that no one picked up yet
+backlog next --labels "bug"         # Ready tasks with the label "bug"
+backlog next --parent "12"          # Ready sub-tasks of task 12
+backlog next --json                 # Print JSON output
+`
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "List the tasks ready to be worked on",
+	Long: `Lists the "todo" tasks whose dependencies are all done, cancelled, archived or in a final status of the workflow.
+Tasks are ordered by decreasing priority, then by dependency depth (tasks earlier in dependency chains first), then by age.`,
+	Example: nextExample,
+	Args:    cobra.NoArgs,
+	RunE:    runNext,
+}
+
+var (
+	nextParent     string
+	nextPriority   string
+	nextAssigned   []string
+	nextLabels     []string
+	nextUnassigned bool
+	nextLimit      int
+	nextHideExtra  bool
+	nextMarkdown   bool
+	nextJSON       bool
+)
+
+func init() {
+	rootCmd.AddCommand(nextCmd)
+	setNextFlags(nextCmd)
+}
+
+func setNextFlags(cmd *cobra.Command) {
+	// filtering
+	cmd.Flags().StringVarP(&nextParent, "parent", "p", "", "Filter tasks by parent ID")
+	cmd.Flags().StringVar(&nextPriority, "priority", "", "Filter tasks by priority")
+	cmd.Flags().StringSliceVarP(&nextAssigned, "assigned", "a", nil, "Filter tasks by assigned names")
+	cmd.Flags().StringSliceVarP(&nextLabels, "labels", "l", nil, "Filter tasks by labels")
+	cmd.Flags().BoolVarP(&nextUnassigned, "unassigned", "u", false, "Filter tasks that have no one assigned")
+	cmd.Flags().IntVar(&nextLimit, "limit", 0, "Maximum number of tasks to return (0 means no limit)")
+	// output
+	cmd.Flags().BoolVarP(&nextHideExtra, "hide-extra", "e", false, "Hide extra fields (labels, priority, due, assigned, progress)")
+	cmd.Flags().BoolVarP(&nextMarkdown, "markdown", "m", false, "print markdown table")
+	cmd.Flags().BoolVarP(&nextJSON, "json", "j", false, "Print JSON output")
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	params := core.ListTasksParams{
+		Parent:     nextParent,
+		Priority:   nextPriority,
+		Assigned:   nextAssigned,
+		Labels:     nextLabels,
+		Unassigned: nextUnassigned,
+		Limit:      nextLimit,
+	}
+
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+
+	listResult, err := store.Next(params)
+	if err != nil {
+		return fmt.Errorf("failed to list next tasks: %w", err)
+	}
+
+	if err := renderTaskResultsWithPagination(cmd.OutOrStdout(), listResult, nextJSON, nextMarkdown, listColumns(nextHideExtra), ""); err != nil {
+		return fmt.Errorf("failed to render task results: %w", err)
+	}
+	return nil
+}
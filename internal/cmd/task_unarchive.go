@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var unarchiveExample = `
+backlog unarchive 10  # restore archived task 10
+`
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <task-id>",
+	Short: "Restore an archived task",
+	Long: `Restores an archived task, moving it back from the archived directory.
+The status the task had before being archived is restored from its history.
+If the task ID has been reused in the meantime, the restored task gets a new ID.`,
+	Example: unarchiveExample,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runUnarchive,
+}
+
+func init() {
+	rootCmd.AddCommand(unarchiveCmd)
+}
+
+func runUnarchive(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	id, err := core.ParseTaskID(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID %q: %v", args[0], err)
+	}
+
+	task, oldPath, err := store.Unarchive(id)
+	if err != nil {
+		return fmt.Errorf("unarchive task %q: %v", args[0], err)
+	}
+
+	logging.Info("task unarchived successfully", "task_id", task.ID, "status", task.Status)
+
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	// Auto-commit the change if enabled
+	commitMsg := fmt.Sprintf("chore(task): unarchive %s - \"%s\"", task.ID, task.Title)
+	if err := commit.Add(store.Path(task), oldPath, commitMsg); err != nil {
+		logging.Warn("auto-commit failed", "task_id", task.ID, "error", err)
+	}
+
+	return nil
+}
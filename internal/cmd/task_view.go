@@ -3,6 +3,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/spf13/cobra"
 	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
@@ -32,6 +35,7 @@ func view(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to view task %q: %w", args[0], err)
 	}
+	timers := t.RunningTimers()
 	t.History = nil // save tokens by not showing the whole history.
 	if viewJSON {
 		if err := json.NewEncoder(cmd.OutOrStdout()).Encode(t); err != nil {
@@ -39,6 +43,16 @@ func view(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		fmt.Printf("%s\n", string(t.Bytes()))
+		if t.Effort != nil {
+			fmt.Printf("Effort, with subtasks: %s\n", t.Effort)
+		}
+		for _, assignee := range slices.Sorted(maps.Keys(timers)) {
+			owner := ""
+			if assignee != "" {
+				owner = " for " + assignee
+			}
+			fmt.Printf("Timer running%s since %s\n", owner, timers[assignee].Local().Format(time.DateTime))
+		}
 	}
 	return nil
 }
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var startExample = `
+backlog start T05                # Start working on task T05, for its single assignee
+backlog start T05 -a alice       # Start a timer for alice, assigned to the task if it has none
+`
+
+var startCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start a timer on a task",
+	Long: `Starts working on a task: sets it in-progress and starts a timer, recorded in its history.
+The timer belongs to the given assignee, one of the task, which can be left out when the task has a single assignee.
+A task without assignee is assigned to the one starting the timer. Each assignee runs one timer at a time.`,
+	Example: startExample,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runStart,
+}
+
+var stopExample = `
+backlog stop                     # Stop the only running timer
+backlog stop T05                 # Stop the timer running on task T05
+backlog stop -a alice            # Stop the timer of alice
+`
+
+var stopCmd = &cobra.Command{
+	Use:   "stop [<id>]",
+	Short: "Stop a running timer",
+	Long: `Stops a running timer and adds the elapsed time, rounded to the minute, to the time spent on the task.
+Without ID nor assignee, stops the only running timer.`,
+	Example: stopExample,
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runStop,
+}
+
+var timerAssignee string
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	startCmd.Flags().StringVarP(&timerAssignee, "assignee", "a", "", "Who works on the task, required unless it has a single assignee")
+	stopCmd.Flags().StringVarP(&timerAssignee, "assignee", "a", "", "Whose timer to stop")
+}
+
+func runStart(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	timer, err := store.StartTimer(core.StartTimerParams{ID: args[0], Assignee: timerAssignee})
+	if err != nil {
+		return fmt.Errorf("failed to start a timer on task %q: %w", args[0], err)
+	}
+	task := timer.Task
+	logging.Info("timer started successfully", "task_id", task.ID, "assignee", timer.Assignee)
+	if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Started a timer on %s%s\n", task.ID.Name(), timerOwner(timer)); err != nil {
+		return err
+	}
+	return commitTimer(store, task, "start timer on")
+}
+
+func runStop(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	params := core.StopTimerParams{Assignee: timerAssignee}
+	if len(args) > 0 {
+		params.ID = args[0]
+	}
+	timer, err := store.StopTimer(params)
+	if err != nil {
+		return fmt.Errorf("failed to stop the timer: %w", err)
+	}
+	task := timer.Task
+	logging.Info("timer stopped successfully", "task_id", task.ID, "assignee", timer.Assignee, "elapsed", timer.Elapsed)
+	if _, err := fmt.Fprintf(cmd.OutOrStdout(), "Stopped the timer on %s%s after %s, %s spent in total\n",
+		task.ID.Name(), timerOwner(timer), timer.Elapsed, task.Spent); err != nil {
+		return err
+	}
+	return commitTimer(store, task, "stop timer on")
+}
+
+func timerOwner(timer core.Timer) string {
+	if timer.Assignee == "" {
+		return ""
+	}
+	return " for " + timer.Assignee
+}
+
+func commitTimer(store mcpserver.TaskStore, task core.Task, msg string) error {
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	commitMsg := fmt.Sprintf("feat(task): %s %s - \"%s\"", msg, task.ID, task.Title)
+	if err := commit.Add(store.Path(task), "", commitMsg); err != nil {
+		logging.Warn("auto-commit failed", "task_id", task.ID, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/veggiemonk/backlog/internal/commit"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var trashExample = `
+backlog trash restore 10  # restore deleted task 10 and its deleted subtasks
+backlog trash empty       # permanently remove all deleted tasks
+`
+
+var trashCmd = &cobra.Command{
+	Use:     "trash",
+	Short:   "Manage deleted tasks",
+	Long:    `Restores or permanently removes the tasks moved to the trash directory by "backlog delete".`,
+	Example: trashExample,
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <task-id>",
+	Short: "Restore a deleted task",
+	Long: `Restores a deleted task, and the subtasks deleted along with it, from the trash directory.
+The parent of the task must not be deleted.
+If the task ID has been reused in the meantime, the restored task gets a new ID.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrashRestore,
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently remove deleted tasks",
+	Long:  `Permanently removes all the tasks in the trash directory. Their IDs can then be reused.`,
+	Args:  cobra.NoArgs,
+	RunE:  runTrashEmpty,
+}
+
+func init() {
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+	rootCmd.AddCommand(trashCmd)
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	id, err := core.ParseTaskID(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid task ID %q: %v", args[0], err)
+	}
+
+	result, err := store.RestoreFromTrash(id)
+	if err != nil {
+		return fmt.Errorf("restore task %q: %v", args[0], err)
+	}
+
+	for _, restored := range result.Restored {
+		logging.Info("task restored successfully", "task_id", restored.Task.ID)
+	}
+
+	if !viper.GetBool(configAutoCommit) {
+		return nil // Auto-commit is disabled
+	}
+	// Auto-commit the change if enabled
+	task := result.Restored[0].Task
+	commitMsg := fmt.Sprintf("chore(task): restore %s - \"%s\"", task.ID, task.Title)
+	if err := commit.AddAll(commitMsg, result.Paths()...); err != nil {
+		logging.Warn("auto-commit failed", "task_id", task.ID, "error", err)
+	}
+
+	return nil
+}
+
+func runTrashEmpty(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	paths, err := store.EmptyTrash()
+	if err != nil {
+		return fmt.Errorf("empty trash: %v", err)
+	}
+	logging.Info("trash emptied", "removed", len(paths))
+
+	if !viper.GetBool(configAutoCommit) || len(paths) == 0 {
+		return nil // Auto-commit is disabled or nothing was removed
+	}
+	commitMsg := fmt.Sprintf("chore(task): empty trash - %d deleted tasks", len(paths))
+	if err := commit.AddAll(commitMsg, paths...); err != nil {
+		logging.Warn("auto-commit failed", "error", err)
+	}
+	return nil
+}
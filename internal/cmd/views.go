@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/veggiemonk/backlog/internal/core"
+	mcpserver "github.com/veggiemonk/backlog/internal/mcp"
+)
+
+var viewsExample = `
+backlog views               # List the saved views
+backlog views --markdown    # Print a markdown table
+backlog views --json        # Print JSON output
+
+# .backlog/views.yaml
+views:
+  triage:
+    description: Bugs and important work to triage
+    where: "status:todo and (label:bug or priority>=high)"
+    sort: [priority]
+    columns: [id, title, priority, labels]
+    limit: 20
+
+backlog list --view triage  # List the tasks of a view
+`
+
+var viewsCmd = &cobra.Command{
+	Use:   "views",
+	Short: "List the saved views",
+	Long: fmt.Sprintf(`Lists the saved views defined in the %s file of the tasks directory.
+A view is a named set of list parameters (filters, sort, reverse, archived, limit, offset)
+using the keys of the task_list MCP tool, with a description and the columns to display (%s).
+Run a view with 'backlog list --view <name>', flags given with --view refine it.`, core.ViewsFile, strings.Join(core.ViewColumns, ", ")),
+	Example: viewsExample,
+	Args:    cobra.NoArgs,
+	RunE:    runViews,
+}
+
+var (
+	viewsMarkdown bool
+	viewsJSON     bool
+)
+
+func init() {
+	rootCmd.AddCommand(viewsCmd)
+	setViewsFlags(viewsCmd)
+}
+
+func setViewsFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&viewsMarkdown, "markdown", "m", false, "print markdown table")
+	cmd.Flags().BoolVarP(&viewsJSON, "json", "j", false, "Print JSON output")
+}
+
+func runViews(cmd *cobra.Command, args []string) error {
+	store := cmd.Context().Value(ctxKeyStore).(mcpserver.TaskStore)
+	views, err := store.Views()
+	if err != nil {
+		return fmt.Errorf("failed to load views: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if viewsJSON {
+		if err := json.NewEncoder(w).Encode(views); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	}
+	if len(views) == 0 {
+		if _, err := fmt.Fprintf(w, "No views found, define them in the %s file of the tasks directory.\n", core.ViewsFile); err != nil {
+			return fmt.Errorf("writer: %v", err)
+		}
+		return nil
+	}
+
+	table := tableWriter(w, viewsMarkdown)
+	table.Header([]string{"Name", "Description", "Columns", "Parameters"})
+	for _, v := range views {
+		params, err := json.Marshal(v.ListTasksParams)
+		if err != nil {
+			return fmt.Errorf("failed to encode view %s: %w", v.Name, err)
+		}
+		row := []string{v.Name, v.Description, strings.Join(v.Columns, ", "), string(params)}
+		if err := table.Append(row); err != nil {
+			return fmt.Errorf("failed to append table row for view %s: %w", v.Name, err)
+		}
+	}
+	if err := table.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
@@ -40,6 +40,17 @@ func FindTopLevelGitDir() (string, error) {
 
 // Add stages and commits files. Will not commit if repo is dirty.
 func Add(path, oldPath, message string) error {
+	if path == "" {
+		logging.Info("no changes to commit")
+		return nil
+	}
+	// oldPath is used in case of a rename (change of title)
+	return AddAll(message, path, oldPath)
+}
+
+// AddAll stages the given files, whether added, modified or removed, and
+// commits them together. Will not commit if other files are staged.
+func AddAll(message string, paths ...string) error {
 	repoRoot, err := FindTopLevelGitDir()
 	if err != nil {
 		return err
@@ -54,17 +65,27 @@ func Add(path, oldPath, message string) error {
 	if err != nil {
 		return fmt.Errorf("could not get worktree: %w", err)
 	}
-	logging.Info("auto-committing changes", "path", path, "oldPath", oldPath, "message", message)
-	if path == "" {
+	logging.Info("auto-committing changes", "paths", paths, "message", message)
+	staged := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if strings.HasPrefix(path, repoRoot) {
+			path = path[len(repoRoot)+1:]
+		}
+		if staged[path] {
+			continue
+		}
+		if _, err = worktree.Add(path); err != nil {
+			return fmt.Errorf("error staging file %s: %w", path, err)
+		}
+		staged[path] = true
+	}
+	if len(staged) == 0 {
 		logging.Info("no changes to commit")
 		return nil
 	}
-	if strings.HasPrefix(path, repoRoot) {
-		path = path[len(repoRoot)+1:]
-	}
-	if _, err = worktree.Add(path); err != nil {
-		return fmt.Errorf("error staging file %s: %w", path, err)
-	}
 	status, err := worktree.Status()
 	if err != nil {
 		return fmt.Errorf("could not get status: %w", err)
@@ -72,21 +93,11 @@ func Add(path, oldPath, message string) error {
 	for fpath, fstat := range status {
 		logging.Info("git status", "path", fpath, "staging", string(fstat.Staging))
 		// If there is another file added, just skip
-		if fpath != path && fstat.Staging == git.Added {
+		if !staged[fpath] && fstat.Staging == git.Added {
 			logging.Warn("the repository status is not clean, skip commit", "status", status.String())
 			return nil
 		}
 	}
-	// used in case of a rename (change of title)
-	if oldPath != "" {
-		if oldPath != "" && strings.HasPrefix(oldPath, repoRoot) {
-			oldPath = oldPath[len(repoRoot)+1:]
-		}
-		_, err := worktree.Add(oldPath)
-		if err != nil {
-			return fmt.Errorf("error staging file %s: %w", path, err)
-		}
-	}
 	opts := &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  "Backlog CLI",
@@ -97,7 +108,7 @@ func Add(path, oldPath, message string) error {
 	if _, err = worktree.Commit(message, opts); err != nil {
 		return fmt.Errorf("error creating commit: %w", err)
 	}
-	logging.Info("changes committed successfully", "path", path, "oldPath", oldPath)
+	logging.Info("changes committed successfully", "paths", paths)
 	return nil
 }
 
@@ -124,6 +135,9 @@ func CheckForTaskConflicts(tasksDir string, autoResolve bool) error {
 		"duplicate_ids", summary.DuplicateIDs,
 		"orphaned_children", summary.OrphanedChildren,
 		"invalid_hierarchy", summary.InvalidHierarchy,
+		"archived_collisions", summary.ArchivedCollisions,
+		"dependency_cycles", summary.DependencyCycles,
+		"dangling_dependencies", summary.DanglingDependencies,
 	)
 
 	// If auto-resolve is enabled, attempt to resolve conflicts
@@ -3,31 +3,95 @@ package core
 import (
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"slices"
 )
 
 // Archive moves a task to the archived directory and updates its status.
+// Tasks with a running timer are not archived.
 func (f *FileTaskStore) Archive(id TaskID) (string, error) {
+	unlock, err := f.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
 	task, err := f.Get(id.String())
 	if err != nil {
 		return "", fmt.Errorf("get task %q: %w", id, err)
 	}
-	if err = f.Update(&task, EditTaskParams{
+	if err := checkNoRunningTimer(task, "archive"); err != nil {
+		return "", err
+	}
+	if _, err = f.update(&task, EditTaskParams{
 		NewStatus: ptr(string(StatusArchived)),
 	}); err != nil {
 		return "", fmt.Errorf("set status archived task %q: %w", id, err)
 	}
 
 	// Move the file to the archived directory.
-	archivedDir := filepath.Join(f.tasksDir, "archived")
-	if err := f.fs.MkdirAll(archivedDir, 0o750); err != nil {
+	archivedPath := filepath.Join(f.tasksDir, archivedDir)
+	if err := f.fs.MkdirAll(archivedPath, 0o750); err != nil {
 		return "", fmt.Errorf("create archived directory: %w", err)
 	}
 	oldPath := f.Path(task)
-	newPath := filepath.Join(archivedDir, filepath.Base(oldPath))
+	newPath := filepath.Join(archivedPath, filepath.Base(oldPath))
 	if err := f.fs.Rename(oldPath, newPath); err != nil {
 		return "", fmt.Errorf("move task file: %w", err)
 	}
 	return newPath, nil
 }
 
+// Unarchive moves an archived task back to the tasks directory and restores the
+// status it had before being archived. If another task took its ID in the
+// meantime, the restored task is renumbered by the conflict resolver.
+// It returns the restored task and the path of the archived file it was moved from.
+func (f *FileTaskStore) Unarchive(id TaskID) (Task, string, error) {
+	unlock, err := f.lock()
+	if err != nil {
+		return Task{}, "", err
+	}
+	defer unlock()
+
+	archived, err := f.findTaskFileIn(id, archivedDir)
+	if err != nil {
+		return Task{}, "", fmt.Errorf("get archived task %q: %w", id, err)
+	}
+	status := statusBeforeArchive(archived.Task)
+
+	task, err := f.restore(archived, archivedDir, "unarchive_id_taken")
+	if err != nil {
+		return Task{}, "", err
+	}
+
+	if _, err := f.update(&task, EditTaskParams{NewStatus: ptr(string(status))}); err != nil {
+		return Task{}, "", fmt.Errorf("restore status of task %q: %w", task.ID, err)
+	}
+	return task, archived.Path, nil
+}
+
+var archivedChangeRegex = regexp.MustCompile(`^Status changed from "([^"]*)" to "archived"$`)
+
+// statusBeforeArchive returns the status the task had before it was archived.
+// It defaults to todo when the history does not record it.
+func statusBeforeArchive(task Task) Status {
+	if task.Status != StatusArchived {
+		return task.Status
+	}
+	for _, entry := range slices.Backward(task.History) {
+		var old string
+		if entry.Type == "status_change" && entry.Metadata["new_status"] == string(StatusArchived) {
+			old, _ = entry.Metadata["old_status"].(string)
+		} else if m := archivedChangeRegex.FindStringSubmatch(entry.Change); m != nil {
+			old = m[1] // history written before status changes carried metadata
+		} else {
+			continue
+		}
+		if status := normalizeStatus(old); status != "" && status != StatusArchived {
+			return status
+		}
+	}
+	return StatusTodo
+}
+
 func ptr[T any](v T) *T { return &v }
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -57,3 +58,99 @@ func TestArchiveTask(t *testing.T) {
 	}
 	is.True(hasArchivedEntry)
 }
+
+func TestUnarchiveTask(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+
+	createdTask, err := store.Create(CreateTaskParams{Title: "Test Task"})
+	is.NoErr(err)
+	is.NoErr(store.Update(&createdTask, EditTaskParams{NewStatus: ptr(string(StatusInProgress))}))
+	archivedPath, err := store.Archive(createdTask.ID)
+	is.NoErr(err)
+
+	restored, fromPath, err := store.Unarchive(createdTask.ID)
+	is.NoErr(err)
+	is.Equal(fromPath, archivedPath)
+	is.Equal(restored.ID.String(), createdTask.ID.String())
+	is.Equal(restored.Status, StatusInProgress) // previous status is restored
+
+	exists, err := afero.Exists(fs, archivedPath)
+	is.NoErr(err)
+	is.True(!exists)
+	task, err := store.Get(createdTask.ID.String())
+	is.NoErr(err)
+	is.Equal(task.Status, StatusInProgress)
+}
+
+func TestUnarchiveTask_IDTaken(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+
+	createdTask, err := store.Create(CreateTaskParams{Title: "Archived Task"})
+	is.NoErr(err)
+	_, err = store.Archive(createdTask.ID)
+	is.NoErr(err)
+	// Simulate the ID being reused by an active task.
+	reused := createdTask
+	reused.Title = "Active Task"
+	is.NoErr(store.write(reused))
+
+	restored, _, err := store.Unarchive(createdTask.ID)
+	is.NoErr(err)
+	is.Equal(restored.ID.String(), "02")
+	is.Equal(restored.Title, "Archived Task")
+	is.Equal(restored.Status, StatusTodo)
+	is.True(restored.HasBeenRenamed())
+
+	active, err := store.Get("01")
+	is.NoErr(err)
+	is.Equal(active.Title, "Active Task")
+}
+
+func TestUnarchiveTask_NotArchived(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	createdTask, err := store.Create(CreateTaskParams{Title: "Active Task"})
+	is.NoErr(err)
+
+	_, _, err = store.Unarchive(createdTask.ID)
+	is.True(errors.Is(err, ErrNotFound))
+}
+
+func TestStatusBeforeArchive_LegacyHistory(t *testing.T) {
+	is := is.New(t)
+	task := NewTask()
+	task.Status = StatusArchived
+	task.History = []HistoryEntry{
+		{Change: `Status changed from "todo" to "in-progress"`, Type: "field_update"},
+		{Change: `Status changed from "in-progress" to "archived"`, Type: "field_update"},
+	}
+	is.Equal(statusBeforeArchive(task), StatusInProgress)
+}
+
+func TestArchivedIDsAreNotReused(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+	two, err := store.Create(CreateTaskParams{Title: "Task Two"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Subtask", Parent: "T02"})
+	is.NoErr(err)
+	_, err = store.Archive(two.ID)
+	is.NoErr(err)
+
+	three, err := store.Create(CreateTaskParams{Title: "Task Three"})
+	is.NoErr(err)
+	is.Equal(three.ID.String(), "03")
+
+	// Archived tasks can still be looked up but not edited.
+	archived, err := store.Get("T02")
+	is.NoErr(err)
+	is.Equal(archived.Status, StatusArchived)
+	err = store.Update(&archived, EditTaskParams{NewTitle: ptr("Edited")})
+	is.True(errors.Is(err, ErrInvalid))
+}
@@ -2,12 +2,14 @@ package core
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/afero"
+	"github.com/veggiemonk/backlog/internal/logging"
 )
 
 // ConflictType represents the type of ID conflict detected
@@ -17,6 +19,10 @@ const (
 	ConflictTypeDuplicateID ConflictType = iota
 	ConflictTypeInvalidHierarchy
 	ConflictTypeOrphanedChild
+	ConflictTypeArchivedCollision
+	ConflictTypeDependencyCycle
+	ConflictTypeDanglingDependency
+	ConflictTypeUnknownStatus
 )
 
 // String returns the string representation of ConflictType
@@ -28,6 +34,14 @@ func (ct ConflictType) String() string {
 		return "invalid_hierarchy"
 	case ConflictTypeOrphanedChild:
 		return "orphaned_child"
+	case ConflictTypeArchivedCollision:
+		return "archived_collision"
+	case ConflictTypeDependencyCycle:
+		return "dependency_cycle"
+	case ConflictTypeDanglingDependency:
+		return "dangling_dependency"
+	case ConflictTypeUnknownStatus:
+		return "unknown_status"
 	default:
 		return "unknown"
 	}
@@ -35,18 +49,23 @@ func (ct ConflictType) String() string {
 
 // IDConflict represents a detected conflict in task IDs
 type IDConflict struct {
-	Type        ConflictType
-	ConflictID  TaskID
-	Files       []string
-	Tasks       []Task
-	Description string
-	DetectedAt  time.Time
+	Type       ConflictType
+	ConflictID TaskID
+	Files      []string
+	Tasks      []Task
+	// Dependencies are the dependencies of a dangling dependency conflict
+	// that are not active tasks.
+	Dependencies []TaskID
+	Description  string
+	DetectedAt   time.Time
 }
 
 // ConflictDetector handles detection and resolution of ID conflicts
 type ConflictDetector struct {
 	fs       afero.Fs
 	tasksDir string
+	index    *taskIndex
+	workflow Workflow
 }
 
 // NewConflictDetector creates a new conflict detector
@@ -54,45 +73,55 @@ func NewConflictDetector(fs afero.Fs, tasksDir string) *ConflictDetector {
 	return &ConflictDetector{
 		fs:       fs,
 		tasksDir: tasksDir,
+		workflow: DefaultWorkflow(),
 	}
 }
 
+// newConflictDetector returns a conflict detector using the workflow of the store.
+func (f *FileTaskStore) newConflictDetector() *ConflictDetector {
+	cd := NewConflictDetector(f.fs, f.tasksDir)
+	cd.SetWorkflow(f.workflow)
+	return cd
+}
+
+// SetWorkflow sets the workflow whose statuses the tasks must use, the default one otherwise.
+func (cd *ConflictDetector) SetWorkflow(w Workflow) {
+	cd.workflow = w
+}
+
 // DetectConflicts scans all task files and identifies ID conflicts
 func (cd *ConflictDetector) DetectConflicts() ([]IDConflict, error) {
 	var conflicts []IDConflict
 
-	// Get all task files
-	files, err := afero.ReadDir(cd.fs, cd.tasksDir)
+	// Get all parsed task files, corrupted files are skipped
+	files, err := cd.loadTasks()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
+		return nil, err
 	}
 
-	// Parse all tasks and build ID map
+	// Build ID maps, archived tasks are tracked separately
 	idToFiles := make(map[string][]string)
 	idToTasks := make(map[string][]Task)
+	archivedFiles := make(map[string][]string)
+	archivedTasks := make(map[string][]Task)
 	allTasks := make([]Task, 0, len(files))
 
 	for _, file := range files {
-		if file.IsDir() || !strings.HasPrefix(file.Name(), TaskIDPrefix) || !strings.HasSuffix(file.Name(), ".md") {
-			continue
-		}
-
-		filePath := filepath.Join(cd.tasksDir, file.Name())
-		task, err := cd.parseTaskFromFile(filePath)
-		if err != nil {
-			// Skip files that can't be parsed - they might be corrupted
+		task := file.Task
+		idStr := task.ID.String()
+		if file.Archived {
+			archivedFiles[idStr] = append(archivedFiles[idStr], file.Path)
+			archivedTasks[idStr] = append(archivedTasks[idStr], task)
 			continue
 		}
-
-		idStr := task.ID.String()
-		idToFiles[idStr] = append(idToFiles[idStr], filePath)
+		idToFiles[idStr] = append(idToFiles[idStr], file.Path)
 		idToTasks[idStr] = append(idToTasks[idStr], task)
 		allTasks = append(allTasks, task)
 	}
 
 	// Detect duplicate IDs
-	for idStr, files := range idToFiles {
-		if len(files) > 1 {
+	for _, idStr := range sortedIDs(idToFiles) {
+		if files := idToFiles[idStr]; len(files) > 1 {
 			id, _ := parseTaskID(idStr)
 			conflicts = append(conflicts, IDConflict{
 				Type:        ConflictTypeDuplicateID,
@@ -105,11 +134,31 @@ func (cd *ConflictDetector) DetectConflicts() ([]IDConflict, error) {
 		}
 	}
 
+	// Detect archived tasks sharing their ID with another task.
+	// Active files are listed first, followed by the archived ones.
+	for _, idStr := range sortedIDs(archivedFiles) {
+		archived := archivedFiles[idStr]
+		if len(archived)+len(idToFiles[idStr]) < 2 {
+			continue
+		}
+		id, _ := parseTaskID(idStr)
+		conflicts = append(conflicts, IDConflict{
+			Type:        ConflictTypeArchivedCollision,
+			ConflictID:  id,
+			Files:       slices.Concat(idToFiles[idStr], archived),
+			Tasks:       slices.Concat(idToTasks[idStr], archivedTasks[idStr]),
+			Description: fmt.Sprintf("Task ID %s is used by archived tasks %v and active tasks %v", idStr, archived, idToFiles[idStr]),
+			DetectedAt:  time.Now(),
+		})
+	}
+
 	// Detect hierarchy conflicts (orphaned children)
 	for _, task := range allTasks {
 		if !task.Parent.IsZero() {
 			parentStr := task.Parent.String()
-			if _, exists := idToTasks[parentStr]; !exists {
+			_, exists := idToTasks[parentStr]
+			_, archived := archivedTasks[parentStr]
+			if !exists && !archived {
 				conflicts = append(conflicts, IDConflict{
 					Type:       ConflictTypeOrphanedChild,
 					ConflictID: task.ID,
@@ -141,9 +190,62 @@ func (cd *ConflictDetector) DetectConflicts() ([]IDConflict, error) {
 		}
 	}
 
+	// Detect dependency cycles and dependencies on tasks that are not active
+	conflicts = append(conflicts, cd.dependencyConflicts(files)...)
+
+	// Detect statuses that are not part of the workflow
+	for _, file := range files {
+		task := file.Task
+		if task.Status == "" || cd.workflow.Known(task.Status) {
+			continue
+		}
+		conflicts = append(conflicts, IDConflict{
+			Type:       ConflictTypeUnknownStatus,
+			ConflictID: task.ID,
+			Files:      []string{file.Path},
+			Tasks:      []Task{task},
+			Description: fmt.Sprintf("Task %s has unknown status %q, valid statuses are %s",
+				task.ID.String(), task.Status, strings.Join(cd.workflow.Names(), ", ")),
+			DetectedAt: time.Now(),
+		})
+	}
+
 	return conflicts, nil
 }
 
+// loadTasks returns the tasks stored in the tasks directory along with their
+// file paths. It relies on the task index so unchanged files are not parsed again.
+func (cd *ConflictDetector) loadTasks() ([]indexedTask, error) {
+	if _, err := cd.fs.Stat(cd.tasksDir); err != nil {
+		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
+	}
+	if cd.index == nil {
+		cd.index = loadTaskIndex(cd.fs, cd.tasksDir)
+	}
+	cd.index.workflow = cd.workflow
+	if err := cd.index.refresh(cd.fs, cd.tasksDir); err != nil {
+		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
+	}
+	if err := cd.index.save(cd.fs, cd.tasksDir); err != nil {
+		logging.Warn("could not save task index", "dir", cd.tasksDir, "error", err)
+	}
+	return cd.index.tasks(cd.tasksDir, ".", archivedDir), nil
+}
+
+// loadActiveTasks is like loadTasks but leaves out archived tasks.
+func (cd *ConflictDetector) loadActiveTasks() ([]indexedTask, error) {
+	files, err := cd.loadTasks()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(files, func(file indexedTask) bool { return file.Archived }), nil
+}
+
+// isArchived reports whether the file is stored in the archived directory.
+func (cd *ConflictDetector) isArchived(filePath string) bool {
+	return filepath.Dir(filePath) == filepath.Join(cd.tasksDir, archivedDir)
+}
+
 // parseTaskFromFile reads and parses a task from a file
 func (cd *ConflictDetector) parseTaskFromFile(filePath string) (task Task, err error) {
 	content, err := afero.ReadFile(cd.fs, filePath)
@@ -155,10 +257,23 @@ func (cd *ConflictDetector) parseTaskFromFile(filePath string) (task Task, err e
 	if err != nil {
 		return task, fmt.Errorf("failed to parse task from %s: %w", filePath, err)
 	}
+	task.Status = cd.workflow.correct(task.Status)
 
 	return task, nil
 }
 
+// sortedIDs returns the task IDs keying the files in numerical order, for the
+// conflicts to be reported and resolved in the same order on every run.
+func sortedIDs(files map[string][]string) []string {
+	ids := slices.Collect(maps.Keys(files))
+	slices.SortFunc(ids, func(a, b string) int {
+		idA, _ := parseTaskID(a)
+		idB, _ := parseTaskID(b)
+		return compareIDs(idA, idB)
+	})
+	return ids
+}
+
 // getTaskFilePath constructs the expected file path for a task ID
 func (cd *ConflictDetector) getTaskFilePath(id TaskID) string {
 	// This is a simplified version - in reality we'd need to find the actual file
@@ -177,7 +292,15 @@ type ConflictSummary struct {
 	DuplicateIDs     int
 	OrphanedChildren int
 	InvalidHierarchy int
-	ConflictsByType  map[ConflictType][]IDConflict
+	// ArchivedCollisions counts IDs shared by archived tasks and other tasks.
+	ArchivedCollisions int
+	// DependencyCycles counts cycles in the dependencies between tasks.
+	DependencyCycles int
+	// DanglingDependencies counts tasks depending on archived, deleted or missing tasks.
+	DanglingDependencies int
+	// UnknownStatuses counts tasks whose status is not part of the workflow.
+	UnknownStatuses int
+	ConflictsByType map[ConflictType][]IDConflict
 }
 
 // SummarizeConflicts creates a summary of the provided conflicts
@@ -197,6 +320,14 @@ func SummarizeConflicts(conflicts []IDConflict) ConflictSummary {
 			summary.OrphanedChildren++
 		case ConflictTypeInvalidHierarchy:
 			summary.InvalidHierarchy++
+		case ConflictTypeArchivedCollision:
+			summary.ArchivedCollisions++
+		case ConflictTypeDependencyCycle:
+			summary.DependencyCycles++
+		case ConflictTypeDanglingDependency:
+			summary.DanglingDependencies++
+		case ConflictTypeUnknownStatus:
+			summary.UnknownStatuses++
 		}
 	}
 
@@ -214,7 +345,7 @@ const (
 
 // ResolutionAction represents an action to resolve a conflict
 type ResolutionAction struct {
-	Type        string // "renumber", "update_parent", "delete"
+	Type        string // "renumber", "update_parent", "remove_dependencies", "manual"
 	OriginalID  TaskID
 	NewID       TaskID
 	FilePath    string
@@ -234,6 +365,7 @@ type ResolutionPlan struct {
 type ConflictResolver struct {
 	detector *ConflictDetector
 	store    *FileTaskStore
+	reserved map[string]bool // IDs already handed out by the plan being created
 }
 
 // NewConflictResolver creates a new conflict resolver
@@ -251,6 +383,7 @@ func (cr *ConflictResolver) CreateResolutionPlan(conflicts []IDConflict, strateg
 		Strategy:  strategy,
 		CreatedAt: time.Now(),
 	}
+	cr.reserved = make(map[string]bool)
 
 	switch strategy {
 	case ResolutionStrategyChronological:
@@ -302,6 +435,13 @@ func (cr *ConflictResolver) createChronologicalPlan(conflicts []IDConflict, plan
 				})
 			}
 		}
+		if conflict.Type == ConflictTypeArchivedCollision {
+			actions, err := cr.archivedCollisionActions(conflict)
+			if err != nil {
+				return nil, err
+			}
+			plan.Actions = append(plan.Actions, actions...)
+		}
 	}
 
 	plan.Summary = fmt.Sprintf("Chronological resolution: %d renumbering actions", len(plan.Actions))
@@ -332,6 +472,13 @@ func (cr *ConflictResolver) createAutoRenumberPlan(conflicts []IDConflict, plan
 				})
 			}
 
+		case ConflictTypeArchivedCollision:
+			actions, err := cr.archivedCollisionActions(conflict)
+			if err != nil {
+				return nil, err
+			}
+			plan.Actions = append(plan.Actions, actions...)
+
 		case ConflictTypeOrphanedChild:
 			// For orphaned children, we can either:
 			// 1. Remove the parent reference (make it a top-level task)
@@ -366,6 +513,47 @@ func (cr *ConflictResolver) createAutoRenumberPlan(conflicts []IDConflict, plan
 					},
 				})
 			}
+
+		case ConflictTypeDanglingDependency:
+			// Dependencies on tasks that are not active anymore are dropped
+			deps := make([]string, len(conflict.Dependencies))
+			for i, dep := range conflict.Dependencies {
+				deps[i] = dep.Name()
+			}
+			plan.Actions = append(plan.Actions, ResolutionAction{
+				Type:        "remove_dependencies",
+				OriginalID:  conflict.ConflictID,
+				FilePath:    conflict.Files[0],
+				Description: fmt.Sprintf("Remove dependencies %v from task %s", deps, conflict.ConflictID.String()),
+				Metadata: map[string]any{
+					"reason":       "dangling_dependency",
+					"dependencies": deps,
+				},
+			})
+
+		case ConflictTypeDependencyCycle:
+			// Which dependency to drop is a decision left to the user
+			plan.Actions = append(plan.Actions, ResolutionAction{
+				Type:        "manual",
+				OriginalID:  conflict.ConflictID,
+				Description: fmt.Sprintf("Break the cycle by removing one of its dependencies: %s", conflict.Description),
+				Metadata: map[string]any{
+					"reason": "dependency_cycle",
+					"files":  conflict.Files,
+				},
+			})
+
+		case ConflictTypeUnknownStatus:
+			// Either the status or the workflow is wrong, the user decides
+			plan.Actions = append(plan.Actions, ResolutionAction{
+				Type:        "manual",
+				OriginalID:  conflict.ConflictID,
+				Description: fmt.Sprintf("Change the status of the task or add it to the workflow: %s", conflict.Description),
+				Metadata: map[string]any{
+					"reason": "unknown_status",
+					"files":  conflict.Files,
+				},
+			})
 		}
 	}
 
@@ -392,23 +580,81 @@ func (cr *ConflictResolver) createManualPlan(conflicts []IDConflict, plan *Resol
 	return plan, nil
 }
 
-// findNextAvailableID finds the next available ID in the sequence
+// archivedCollisionActions renumbers the archived tasks of an archived collision.
+// Active tasks keep their ID; when only archived tasks share the ID, the first
+// one found keeps it.
+func (cr *ConflictResolver) archivedCollisionActions(conflict IDConflict) ([]ResolutionAction, error) {
+	var actions []ResolutionAction
+	keep := ""
+	for i, file := range conflict.Files {
+		if !cr.detector.isArchived(file) {
+			keep = file // an active task owns the ID
+			continue
+		}
+		if keep == "" {
+			keep = file
+			continue
+		}
+		task := conflict.Tasks[i]
+		newID, err := cr.findNextAvailableID(task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find available ID for %s: %w", task.ID.String(), err)
+		}
+		actions = append(actions, ResolutionAction{
+			Type:        "renumber",
+			OriginalID:  task.ID,
+			NewID:       newID,
+			FilePath:    file,
+			Description: fmt.Sprintf("Renumber archived task %s to %s", task.ID.String(), newID.String()),
+			Metadata: map[string]any{
+				"reason":    "archived_id_collision",
+				"kept_file": keep,
+			},
+		})
+	}
+	return actions, nil
+}
+
+// findNextAvailableID finds the next available ID in the sequence.
+// IDs handed out earlier for the same plan are skipped.
 func (cr *ConflictResolver) findNextAvailableID(conflictID TaskID) (TaskID, error) {
 	// Use the store's getNextTaskID method to find the next available ID
 	// For hierarchical IDs, we need to consider the parent path
+	var treePath []int
 	if conflictID.HasSubTasks() {
-		parent := conflictID.Parent()
-		if parent != nil {
-			return cr.store.getNextTaskID(parent.seg...)
+		if parent := conflictID.Parent(); parent != nil {
+			treePath = parent.seg
 		}
 	}
 
-	// For top-level tasks, find the next available top-level ID
-	return cr.store.getNextTaskID()
+	id, err := cr.store.getNextTaskID(treePath...)
+	if err != nil {
+		return id, err
+	}
+	for cr.reserved[id.String()] {
+		id = id.NextSiblingID()
+	}
+	if cr.reserved != nil {
+		cr.reserved[id.String()] = true
+	}
+	return id, nil
 }
 
 // ExecuteResolutionPlan executes the given resolution plan
 func (cr *ConflictResolver) ExecuteResolutionPlan(plan *ResolutionPlan, dryRun bool) ([]string, error) {
+	if !dryRun {
+		unlock, err := cr.store.lock()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	return cr.executeResolutionPlan(plan, dryRun)
+}
+
+// executeResolutionPlan executes the given resolution plan, with the tasks
+// directory locked unless it is a dry run.
+func (cr *ConflictResolver) executeResolutionPlan(plan *ResolutionPlan, dryRun bool) ([]string, error) {
 	results := make([]string, 0, len(plan.Actions))
 	if dryRun {
 		results = append(results, "DRY RUN MODE - No changes will be made")
@@ -430,6 +676,8 @@ func (cr *ConflictResolver) executeAction(action ResolutionAction, dryRun bool)
 		return cr.executeRenumberAction(action, dryRun)
 	case "update_parent":
 		return cr.executeUpdateParentAction(action, dryRun)
+	case "remove_dependencies":
+		return cr.executeRemoveDependenciesAction(action, dryRun)
 	case "manual":
 		return fmt.Sprintf("MANUAL: %s", action.Description), nil
 	default:
@@ -454,12 +702,12 @@ func (cr *ConflictResolver) executeRenumberAction(action ResolutionAction, dryRu
 	task.ID = action.NewID
 
 	// Record the change in history using enhanced tracking
-	RecordIDChange(task, oldID, action.NewID, "conflict resolution", action.Metadata)
-	task.UpdatedAt = time.Now()
+	RecordIDChange(&task, oldID, action.NewID, "conflict resolution", action.Metadata)
+	task.UpdatedAt = time.Now().UTC()
 
-	// Create new file with new ID
-	newFilePath := cr.store.Path(task)
-	if err := cr.store.write(task); err != nil {
+	// Create new file with new ID, next to the original one
+	newFilePath := filepath.Join(filepath.Dir(action.FilePath), task.FileName())
+	if err := cr.store.writeFile(newFilePath, task); err != nil {
 		return "", fmt.Errorf("failed to write updated task: %w", err)
 	}
 
@@ -492,7 +740,7 @@ func (cr *ConflictResolver) executeUpdateParentAction(action ResolutionAction, d
 
 	// Record the change in history using enhanced tracking
 	RecordParentChange(&task, oldParent, action.NewID, "conflict resolution")
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = time.Now().UTC()
 
 	// Write the updated task
 	if err := cr.store.write(task); err != nil {
@@ -505,6 +753,34 @@ func (cr *ConflictResolver) executeUpdateParentAction(action ResolutionAction, d
 	return fmt.Sprintf("UPDATED PARENT: %s -> %s", oldParent.String(), action.NewID.String()), nil
 }
 
+// executeRemoveDependenciesAction executes a dependency removal action
+func (cr *ConflictResolver) executeRemoveDependenciesAction(action ResolutionAction, dryRun bool) (string, error) {
+	deps, _ := action.Metadata["dependencies"].([]string)
+	if dryRun {
+		return fmt.Sprintf("WOULD REMOVE DEPENDENCIES: %s %v", action.OriginalID.String(), deps), nil
+	}
+
+	// Read the task
+	task, err := cr.detector.parseTaskFromFile(action.FilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read task: %w", err)
+	}
+
+	remaining := slices.DeleteFunc(task.Dependencies.ToSlice(), func(dep string) bool {
+		id, err := parseTaskID(dep)
+		return err == nil && slices.Contains(deps, id.Name())
+	})
+	RecordChange(&task, fmt.Sprintf("Removed dependencies %v (conflict resolution)", deps))
+	task.Dependencies = MaybeStringArrayFromSlice(remaining)
+	task.UpdatedAt = time.Now().UTC()
+
+	// Write the updated task
+	if err := cr.store.write(task); err != nil {
+		return "", fmt.Errorf("failed to write updated task: %w", err)
+	}
+	return fmt.Sprintf("REMOVED DEPENDENCIES: %s %v", action.OriginalID.String(), deps), nil
+}
+
 // ReferenceUpdater handles updating references when task IDs change
 type ReferenceUpdater struct {
 	detector *ConflictDetector
@@ -519,30 +795,22 @@ func NewReferenceUpdater(detector *ConflictDetector, store *FileTaskStore) *Refe
 	}
 }
 
-// UpdateReferences updates all references to changed task IDs
-func (ru *ReferenceUpdater) UpdateReferences(idChanges map[string]TaskID) error {
+// UpdateReferences updates all references to changed task IDs and returns
+// the updated tasks.
+func (ru *ReferenceUpdater) UpdateReferences(idChanges map[string]TaskID) ([]Task, error) {
 	if len(idChanges) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	// Get all task files
-	files, err := afero.ReadDir(ru.detector.fs, ru.detector.tasksDir)
+	// Get all active task files, corrupted files are skipped
+	files, err := ru.detector.loadActiveTasks()
 	if err != nil {
-		return fmt.Errorf("failed to read tasks directory: %w", err)
+		return nil, err
 	}
 
 	var updatedTasks []Task
 	for _, file := range files {
-		if file.IsDir() || !strings.HasPrefix(file.Name(), TaskIDPrefix) || !strings.HasSuffix(file.Name(), ".md") {
-			continue
-		}
-
-		filePath := filepath.Join(ru.detector.tasksDir, file.Name())
-		task, err := ru.detector.parseTaskFromFile(filePath)
-		if err != nil {
-			continue // Skip corrupted files
-		}
-
+		task := file.Task
 		updated := false
 
 		// Update parent references
@@ -566,7 +834,7 @@ func (ru *ReferenceUpdater) UpdateReferences(idChanges map[string]TaskID) error
 				}
 
 				if newDepID, exists := idChanges[depID.String()]; exists {
-					newDeps = append(newDeps, newDepID.String())
+					newDeps = append(newDeps, newDepID.Name())
 					depsUpdated = true
 				} else {
 					newDeps = append(newDeps, dep)
@@ -582,7 +850,7 @@ func (ru *ReferenceUpdater) UpdateReferences(idChanges map[string]TaskID) error
 		}
 
 		if updated {
-			task.UpdatedAt = time.Now()
+			task.UpdatedAt = time.Now().UTC()
 			updatedTasks = append(updatedTasks, task)
 		}
 	}
@@ -590,36 +858,60 @@ func (ru *ReferenceUpdater) UpdateReferences(idChanges map[string]TaskID) error
 	// Write all updated tasks
 	for _, task := range updatedTasks {
 		if err := ru.store.write(task); err != nil {
-			return fmt.Errorf("failed to write updated task %s: %w", task.ID.String(), err)
+			return nil, fmt.Errorf("failed to write updated task %s: %w", task.ID.String(), err)
 		}
 	}
 
-	return nil
+	return updatedTasks, nil
+}
+
+// RemoveReferences removes the given task IDs from the dependencies of all
+// active tasks and returns the updated tasks.
+func (ru *ReferenceUpdater) RemoveReferences(ids []TaskID) ([]Task, error) {
+	files, err := ru.detector.loadActiveTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTasks []Task
+	for _, file := range files {
+		task := file.Task
+		deps := task.Dependencies.ToSlice()
+		kept := slices.DeleteFunc(slices.Clone(deps), func(dep string) bool {
+			depID, err := parseTaskID(dep)
+			return err == nil && slices.ContainsFunc(ids, depID.Equals)
+		})
+		if len(kept) == len(deps) {
+			continue
+		}
+		task.Dependencies = MaybeStringArrayFromSlice(kept)
+		RecordChange(&task, fmt.Sprintf("Removed dependencies on deleted tasks: %q", slices.DeleteFunc(deps, func(dep string) bool {
+			return slices.Contains(kept, dep)
+		})))
+		task.UpdatedAt = time.Now().UTC()
+		if err := ru.store.write(task); err != nil {
+			return nil, fmt.Errorf("failed to write updated task %s: %w", task.ID.String(), err)
+		}
+		updatedTasks = append(updatedTasks, task)
+	}
+	return updatedTasks, nil
 }
 
 // FindTaskReferences finds all tasks that reference the given task ID
 func (ru *ReferenceUpdater) FindTaskReferences(targetID TaskID) ([]Task, error) {
 	var referencingTasks []Task
 
-	// Get all task files
-	files, err := afero.ReadDir(ru.detector.fs, ru.detector.tasksDir)
+	// Get all active task files, corrupted files are skipped
+	files, err := ru.detector.loadActiveTasks()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read tasks directory: %w", err)
+		return nil, err
 	}
 
 	targetIDStr := targetID.String()
 	targetIDName := targetID.Name() // With "T" prefix
 
 	for _, file := range files {
-		if file.IsDir() || !strings.HasPrefix(file.Name(), TaskIDPrefix) || !strings.HasSuffix(file.Name(), ".md") {
-			continue
-		}
-
-		filePath := filepath.Join(ru.detector.tasksDir, file.Name())
-		task, err := ru.detector.parseTaskFromFile(filePath)
-		if err != nil {
-			continue // Skip corrupted files
-		}
+		task := file.Task
 
 		// Check parent reference
 		if task.Parent.String() == targetIDStr {
@@ -651,6 +943,13 @@ func (cr *ConflictResolver) ExecuteResolutionPlanWithReferences(plan *Resolution
 	var results []string
 	idChanges := make(map[string]TaskID)
 
+	if !dryRun {
+		unlock, err := cr.store.lock()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 	if dryRun {
 		results = append(results, "DRY RUN MODE - No changes will be made")
 	}
@@ -663,8 +962,9 @@ func (cr *ConflictResolver) ExecuteResolutionPlanWithReferences(plan *Resolution
 		}
 		results = append(results, result)
 
-		// Collect ID changes for reference updating
-		if action.Type == "renumber" && !dryRun {
+		// Collect ID changes for reference updating. References never point
+		// to archived tasks renumbered out of the way of an active one.
+		if action.Type == "renumber" && !dryRun && !cr.detector.isArchived(action.FilePath) {
 			idChanges[action.OriginalID.String()] = action.NewID
 		}
 	}
@@ -672,7 +972,7 @@ func (cr *ConflictResolver) ExecuteResolutionPlanWithReferences(plan *Resolution
 	// Second pass: update all references to changed IDs
 	if !dryRun && len(idChanges) > 0 {
 		updater := NewReferenceUpdater(cr.detector, cr.store)
-		if err := updater.UpdateReferences(idChanges); err != nil {
+		if _, err := updater.UpdateReferences(idChanges); err != nil {
 			return results, fmt.Errorf("failed to update references: %w", err)
 		}
 
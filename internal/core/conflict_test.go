@@ -1,6 +1,7 @@
 package core
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ func TestConflictType_String(t *testing.T) {
 		{ConflictTypeDuplicateID, "duplicate_id"},
 		{ConflictTypeInvalidHierarchy, "invalid_hierarchy"},
 		{ConflictTypeOrphanedChild, "orphaned_child"},
+		{ConflictTypeArchivedCollision, "archived_collision"},
 		{ConflictType(999), "unknown"},
 	}
 
@@ -277,6 +279,99 @@ Invalid hierarchy.
 	is.True(conflictTypes[ConflictTypeInvalidHierarchy])
 }
 
+func TestConflictDetector_DetectConflicts_ArchivedCollision(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+
+	_, err := store.Create(CreateTaskParams{Title: "Archived Task"})
+	is.NoErr(err)
+	_, err = store.Archive(mustParseTaskID("01"))
+	is.NoErr(err)
+	dependent, err := store.Create(CreateTaskParams{Title: "Dependent"})
+	is.NoErr(err)
+
+	// Simulate a task created with the archived ID, e.g. on another branch
+	// or by an older version of backlog.
+	reused := Task{ID: mustParseTaskID("01"), Title: "Reused ID", Status: StatusTodo, CreatedAt: time.Now()}
+	is.NoErr(store.write(reused))
+	is.NoErr(store.Update(&dependent, EditTaskParams{NewDependencies: []string{"T01"}}))
+
+	detector := NewConflictDetector(fs, ".backlog")
+	conflicts, err := detector.DetectConflicts()
+	is.NoErr(err)
+	is.Equal(len(conflicts), 1)
+	is.Equal(conflicts[0].Type, ConflictTypeArchivedCollision)
+	is.Equal(conflicts[0].ConflictID.String(), "01")
+	is.Equal(conflicts[0].Files, []string{
+		filepath.Join(".backlog", "T01-reused_id.md"),
+		filepath.Join(".backlog", "archived", "T01-archived_task.md"),
+	})
+
+	resolver := NewConflictResolver(detector, store)
+	plan, err := resolver.CreateResolutionPlan(conflicts, ResolutionStrategyChronological)
+	is.NoErr(err)
+	is.Equal(len(plan.Actions), 1)
+	is.Equal(plan.Actions[0].FilePath, filepath.Join(".backlog", "archived", "T01-archived_task.md"))
+	is.Equal(plan.Actions[0].NewID.String(), "03")
+
+	_, err = resolver.ExecuteResolutionPlanWithReferences(plan, false)
+	is.NoErr(err)
+
+	// The archived task is renumbered in place, the active task keeps its ID
+	// and references to it are left untouched.
+	exists, err := afero.Exists(fs, filepath.Join(".backlog", "archived", "T03-archived_task.md"))
+	is.NoErr(err)
+	is.True(exists)
+	task, err := store.Get("01")
+	is.NoErr(err)
+	is.Equal(task.Title, "Reused ID")
+	dependent, err = store.Get("02")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T01"})
+
+	conflicts, err = detector.DetectConflicts()
+	is.NoErr(err)
+	is.Equal(len(conflicts), 0)
+}
+
+func TestConflictDetector_DetectConflicts_ArchivedCollisionOrder(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+
+	for _, title := range []string{"One", "Two", "Three"} {
+		task, err := store.Create(CreateTaskParams{Title: title})
+		is.NoErr(err)
+		_, err = store.Archive(task.ID)
+		is.NoErr(err)
+	}
+	for _, id := range []string{"03", "01", "02"} {
+		is.NoErr(store.write(Task{ID: mustParseTaskID(id), Title: "Reused " + id, Status: StatusTodo, CreatedAt: time.Now()}))
+	}
+
+	// The archived tasks are renumbered in the order of their IDs, whatever
+	// the order of the map they are collected in.
+	detector := NewConflictDetector(fs, ".backlog")
+	for range 10 {
+		conflicts, err := detector.DetectConflicts()
+		is.NoErr(err)
+		var ids []string
+		for _, conflict := range conflicts {
+			ids = append(ids, conflict.ConflictID.String())
+		}
+		is.Equal(ids, []string{"01", "02", "03"})
+
+		plan, err := NewConflictResolver(detector, store).CreateResolutionPlan(conflicts, ResolutionStrategyChronological)
+		is.NoErr(err)
+		var newIDs []string
+		for _, action := range plan.Actions {
+			newIDs = append(newIDs, action.NewID.String())
+		}
+		is.Equal(newIDs, []string{"04", "05", "06"})
+	}
+}
+
 func TestSummarizeConflicts(t *testing.T) {
 	is := is.New(t)
 
@@ -285,10 +380,12 @@ func TestSummarizeConflicts(t *testing.T) {
 		{Type: ConflictTypeDuplicateID, ConflictID: mustParseTaskID("02")},
 		{Type: ConflictTypeOrphanedChild, ConflictID: mustParseTaskID("03.01")},
 		{Type: ConflictTypeInvalidHierarchy, ConflictID: mustParseTaskID("04.01")},
+		{Type: ConflictTypeArchivedCollision, ConflictID: mustParseTaskID("05")},
 	}
 
 	summary := SummarizeConflicts(conflicts)
-	is.Equal(summary.TotalConflicts, 4)
+	is.Equal(summary.TotalConflicts, 5)
+	is.Equal(summary.ArchivedCollisions, 1)
 	is.Equal(summary.DuplicateIDs, 2)
 	is.Equal(summary.OrphanedChildren, 1)
 	is.Equal(summary.InvalidHierarchy, 1)
@@ -482,7 +579,7 @@ func TestReferenceUpdater_UpdateReferences_EmptyChanges(t *testing.T) {
 	store := NewFileTaskStore(fs, ".backlog")
 	updater := NewReferenceUpdater(detector, store)
 
-	err := updater.UpdateReferences(map[string]TaskID{})
+	_, err := updater.UpdateReferences(map[string]TaskID{})
 	is.NoErr(err) // Should succeed with no changes
 }
 
@@ -19,6 +19,13 @@ type CreateTaskParams struct {
 	AC           []string `json:"ac,omitempty"           jsonschema:"A list of acceptance criteria."`
 	Plan         string   `json:"plan,omitempty"         jsonschema:"The implementation plan."`
 	Notes        string   `json:"notes,omitempty"        jsonschema:"Additional notes."`
+	// Start and Due are dates, see ParseDate.
+	Start string `json:"start,omitempty" jsonschema:"The day work on the task starts: YYYY-MM-DD, today, tomorrow, a weekday like friday or a number of days like +3d."`
+	Due   string `json:"due,omitempty"   jsonschema:"The day the task is due: YYYY-MM-DD, today, tomorrow, a weekday like friday or a number of days like +3d."`
+	// Estimate is in points.
+	Estimate float64 `json:"estimate,omitempty" jsonschema:"The estimate of the task in points."`
+	// Fields are the values of the custom fields, see FieldDef.
+	Fields map[string]any `json:"fields,omitempty" jsonschema:"The values of the custom fields of the repository."`
 }
 
 // Create implements TaskStore.
@@ -32,6 +39,12 @@ func (f *FileTaskStore) Create(params CreateTaskParams) (newTask Task, err error
 			return newTask, fmt.Errorf("could not create tasks directory %q: %w", f.tasksDir, err)
 		}
 	}
+	unlock, err := f.lock()
+	if err != nil {
+		return newTask, err
+	}
+	defer unlock()
+
 	var parentID TaskID
 	if params.Parent != "" {
 		parentID, err = parseTaskID(params.Parent)
@@ -62,6 +75,9 @@ func (f *FileTaskStore) Create(params CreateTaskParams) (newTask Task, err error
 		}
 		deps = append(deps, depID.Name())
 	}
+	if err := f.validateDependencies(nextID, deps); err != nil {
+		return newTask, err
+	}
 
 	newTask = NewTask()
 	newTask.ID = nextID
@@ -75,13 +91,29 @@ func (f *FileTaskStore) Create(params CreateTaskParams) (newTask Task, err error
 	if err != nil {
 		return newTask, fmt.Errorf("invalid priority %q: %w", params.Priority, err)
 	}
+	now := time.Now()
+	if newTask.Start, err = ParseDate(params.Start, now); err != nil {
+		return newTask, fmt.Errorf("invalid start date: %w", err)
+	}
+	if newTask.Due, err = ParseDate(params.Due, now); err != nil {
+		return newTask, fmt.Errorf("invalid due date: %w", err)
+	}
+	if err := checkDates(newTask); err != nil {
+		return newTask, err
+	}
+	if newTask.Estimate, err = parseEstimate(params.Estimate); err != nil {
+		return newTask, err
+	}
 	if params.Notes != "" {
 		newTask.ImplementationNotes = fmt.Sprintf("%s\n", params.Notes)
 	}
 	if params.Plan != "" {
 		newTask.ImplementationPlan = fmt.Sprintf("%s\n", params.Plan)
 	}
-	newTask.CreatedAt = time.Now().UTC()
+	if err := f.setFields(&newTask, params.Fields, false); err != nil {
+		return newTask, err
+	}
+	newTask.CreatedAt = now.UTC()
 
 	for i, criterion := range params.AC {
 		newTask.AcceptanceCriteria = append(newTask.AcceptanceCriteria, AcceptanceCriterion{
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// CriticalPathParams holds the parameters for analyzing the dependencies of the unfinished tasks.
+type CriticalPathParams struct {
+	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of blocking tasks to return (0 means no limit)."`
+}
+
+// BlockingTask is an unfinished task with the number of unfinished tasks
+// depending on it, directly or through other tasks.
+type BlockingTask struct {
+	Task   Task `json:"task"`
+	Blocks int  `json:"blocks"`
+}
+
+// CriticalPathResult is the result of a critical path analysis.
+type CriticalPathResult struct {
+	// Path is the longest chain of unfinished tasks depending on each other,
+	// starting with the task to work on first.
+	Path []BlockingTask `json:"path"`
+	// Blockers are the unfinished tasks blocking other tasks, the ones
+	// unblocking the most work first.
+	Blockers []BlockingTask `json:"blockers"`
+}
+
+// CriticalPath computes the longest dependency chain over the unfinished
+// tasks, and how many unfinished tasks each of them blocks transitively.
+// Tasks that are done, cancelled, archived or in a final status of the workflow do not block anything.
+func (f *FileTaskStore) CriticalPath(params CriticalPathParams) (CriticalPathResult, error) {
+	result := CriticalPathResult{Path: []BlockingTask{}, Blockers: []BlockingTask{}}
+	active, err := f.loadAll(".")
+	if err != nil {
+		return result, fmt.Errorf("loading tasks: %v", err)
+	}
+	archived, err := f.loadAll(archivedDir)
+	if err != nil {
+		return result, fmt.Errorf("loading archived tasks: %v", err)
+	}
+	resolved := resolvedTasks(active, archived, f.workflow)
+	byID := make(map[string]Task, len(active))
+	for _, t := range active {
+		if !resolved[t.ID.String()] {
+			byID[t.ID.String()] = t
+		}
+	}
+	g := make(dependencyGraph, len(byID))
+	for id, t := range byID {
+		g[id] = []string{}
+		for _, dep := range dependencyIDs(t.Dependencies) {
+			if _, ok := byID[dep]; ok {
+				g[id] = append(g[id], dep)
+			}
+		}
+		slices.SortFunc(g[id], compareIDStrings)
+	}
+
+	blocks := g.dependentCounts()
+	for id, n := range blocks {
+		if n > 0 {
+			result.Blockers = append(result.Blockers, BlockingTask{Task: byID[id], Blocks: n})
+		}
+	}
+	slices.SortFunc(result.Blockers, func(a, b BlockingTask) int {
+		if a.Blocks != b.Blocks {
+			return b.Blocks - a.Blocks
+		}
+		if a.Task.Priority != b.Task.Priority {
+			return int(b.Task.Priority) - int(a.Task.Priority)
+		}
+		return compareIDs(a.Task.ID, b.Task.ID)
+	})
+	if params.Limit > 0 && len(result.Blockers) > params.Limit {
+		result.Blockers = result.Blockers[:params.Limit]
+	}
+
+	for _, id := range g.longestChain() {
+		result.Path = append(result.Path, BlockingTask{Task: byID[id], Blocks: blocks[id]})
+	}
+	return result, nil
+}
+
+// longestChain returns the longest chain of dependencies of the graph, from
+// the task without dependencies to the task depending on all the others.
+// Ties are broken by the lowest task IDs. Cycles are cut where they are entered.
+func (g dependencyGraph) longestChain() []string {
+	lengths := make(map[string]int, len(g))
+	previous := make(map[string]string, len(g))
+	visiting := make(map[string]bool)
+	var length func(string) int
+	length = func(id string) int {
+		if l, ok := lengths[id]; ok {
+			return l
+		}
+		if visiting[id] {
+			return 0
+		}
+		visiting[id] = true
+		l := 1
+		for _, dep := range g[id] {
+			if dl := length(dep) + 1; dl > l {
+				l = dl
+				previous[id] = dep
+			}
+		}
+		visiting[id] = false
+		lengths[id] = l
+		return l
+	}
+	ids := make([]string, 0, len(g))
+	for id := range g {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, compareIDStrings)
+	last := ""
+	for _, id := range ids {
+		if last == "" || length(id) > lengths[last] {
+			last = id
+		}
+	}
+	if last == "" {
+		return nil
+	}
+	chain := []string{last}
+	for id, ok := previous[last]; ok; id, ok = previous[id] {
+		chain = append(chain, id)
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// dependentCounts returns, for each task, the number of tasks depending on
+// it directly or transitively.
+func (g dependencyGraph) dependentCounts() map[string]int {
+	dependents := make(map[string][]string, len(g))
+	for id, deps := range g {
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+	counts := make(map[string]int, len(g))
+	for id := range g {
+		seen := map[string]bool{id: true}
+		queue := []string{id}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			for _, d := range dependents[n] {
+				if !seen[d] {
+					seen[d] = true
+					queue = append(queue, d)
+				}
+			}
+		}
+		counts[id] = len(seen) - 1
+	}
+	return counts
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestCriticalPath(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Done"},
+		{Title: "Schema", Dependencies: []string{"T01"}},
+		{Title: "API", Priority: "high", Dependencies: []string{"T02"}},
+		{Title: "UI", Dependencies: []string{"T03"}},
+		{Title: "Docs", Dependencies: []string{"T03"}},
+		{Title: "Release", Dependencies: []string{"T04", "T05"}},
+		{Title: "Infra", Priority: "critical"},
+		{Title: "Monitoring", Dependencies: []string{"T07"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	done, err := store.Get("T01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&done, EditTaskParams{ID: "T01", NewStatus: ptr("done")}))
+
+	type entry struct {
+		title  string
+		blocks int
+	}
+	entries := func(tasks []BlockingTask) []entry {
+		var entries []entry
+		for _, bt := range tasks {
+			entries = append(entries, entry{bt.Task.Title, bt.Blocks})
+		}
+		return entries
+	}
+
+	result, err := store.CriticalPath(CriticalPathParams{})
+	is.NoErr(err)
+	// The finished task is not on the path anymore.
+	is.Equal(entries(result.Path), []entry{{"Schema", 4}, {"API", 3}, {"UI", 1}, {"Release", 0}})
+	is.Equal(entries(result.Blockers), []entry{{"Schema", 4}, {"API", 3}, {"Infra", 1}, {"UI", 1}, {"Docs", 1}})
+
+	result, err = store.CriticalPath(CriticalPathParams{Limit: 2})
+	is.NoErr(err)
+	is.Equal(entries(result.Blockers), []entry{{"Schema", 4}, {"API", 3}})
+}
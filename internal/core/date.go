@@ -0,0 +1,130 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// Date is a day without a time, such as the due date of a task, written
+// YYYY-MM-DD. The empty date means no date.
+type Date string
+
+var (
+	_ yaml.Unmarshaler = (*Date)(nil)
+	_ yaml.Marshaler   = (*Date)(nil)
+)
+
+// Time returns the start of the day in UTC, the zero time for the empty date.
+func (d Date) Time() time.Time {
+	t, _ := time.Parse(time.DateOnly, string(d))
+	return t
+}
+
+func (d Date) String() string { return string(d) }
+
+// MarshalYAML implements yaml.Marshaler, writing the date unquoted like the
+// times of the front matter.
+func (d Date) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!timestamp", Value: string(d)}, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting a date or a time, of
+// which the day is kept.
+func (d *Date) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("date: want YYYY-MM-DD, got %q: %w", value.Tag, ErrInvalid)
+	}
+	if value.Value == "" {
+		*d = ""
+		return nil
+	}
+	if t, err := time.Parse(time.DateOnly, value.Value); err == nil {
+		*d = DateOf(t)
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value.Value)
+	if err != nil {
+		return fmt.Errorf("date %q (want YYYY-MM-DD): %w", value.Value, ErrInvalid)
+	}
+	*d = DateOf(t)
+	return nil
+}
+
+// DateOf returns the day of a time, in the location of the time.
+func DateOf(t time.Time) Date {
+	return Date(t.Format(time.DateOnly))
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// ParseDate parses a date given by a user: YYYY-MM-DD, an RFC 3339 time, or a
+// day relative to now: today, tomorrow, yesterday, a weekday such as friday
+// or fri, meaning the next one after today, or a signed number of days or
+// weeks such as +3d, +2w or -1d. The empty string is the empty date.
+func ParseDate(s string, now time.Time) (Date, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return "", nil
+	case "today":
+		return DateOf(now), nil
+	case "tomorrow":
+		return DateOf(now.AddDate(0, 0, 1)), nil
+	case "yesterday":
+		return DateOf(now.AddDate(0, 0, -1)), nil
+	}
+	for name, day := range weekdays {
+		if s == name || s == name[:3] {
+			days := (int(day)-int(now.Weekday())+6)%7 + 1
+			return DateOf(now.AddDate(0, 0, days)), nil
+		}
+	}
+	if (s[0] == '+' || s[0] == '-') && len(s) > 2 {
+		units := map[byte]int{'d': 1, 'w': 7}
+		if unit, ok := units[s[len(s)-1]]; ok {
+			if n, err := strconv.Atoi(s[1 : len(s)-1]); err == nil {
+				if s[0] == '-' {
+					n = -n
+				}
+				return DateOf(now.AddDate(0, 0, n*unit)), nil
+			}
+		}
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return DateOf(t), nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return DateOf(t), nil
+	}
+	return "", fmt.Errorf("date %q (want YYYY-MM-DD, today, tomorrow, a weekday or a number of days like +3d): %w", s, ErrInvalid)
+}
+
+// compareDates compares dates, the empty date coming after the others.
+func compareDates(a, b Date) int {
+	if a == "" || b == "" {
+		return cmp.Compare(boolInt(a == ""), boolInt(b == ""))
+	}
+	return strings.Compare(string(a), string(b))
+}
+
+// checkDates returns an error if the task starts after it is due.
+func checkDates(task Task) error {
+	if task.Start != "" && task.Due != "" && task.Start > task.Due {
+		return fmt.Errorf("start date %s is after the due date %s: %w", task.Start, task.Due, ErrInvalid)
+	}
+	return nil
+}
+
+// Overdue reports whether the task is due before the given day and its status
+// is not resolved in the workflow.
+func (t Task) Overdue(today Date, w Workflow) bool {
+	return t.Due != "" && t.Due < today && !w.resolved(t.Status)
+}
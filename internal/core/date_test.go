@@ -0,0 +1,99 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestParseDate(t *testing.T) {
+	is := is.New(t)
+	now := time.Date(2025, 9, 17, 18, 30, 0, 0, time.UTC) // a Wednesday
+
+	for in, want := range map[string]Date{
+		"":                     "",
+		"2025-10-01":           "2025-10-01",
+		"2025-10-01T23:00:00Z": "2025-10-01",
+		"today":                "2025-09-17",
+		"Tomorrow":             "2025-09-18",
+		"yesterday":            "2025-09-16",
+		"friday":               "2025-09-19",
+		"fri":                  "2025-09-19",
+		"wednesday":            "2025-09-24", // the next one
+		"monday":               "2025-09-22",
+		"+3d":                  "2025-09-20",
+		"+2w":                  "2025-10-01",
+		"-1d":                  "2025-09-16",
+	} {
+		got, err := ParseDate(in, now)
+		is.NoErr(err)
+		is.Equal(got, want) // date of in
+	}
+
+	for _, in := range []string{"3d", "next week", "2025-13-01", "+d", "+3m"} {
+		_, err := ParseDate(in, now)
+		is.True(errors.Is(err, ErrInvalid)) // invalid date
+	}
+}
+
+func TestDates(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	today := DateOf(time.Now())
+
+	late, err := store.Create(CreateTaskParams{Title: "Late", Start: "-7d", Due: "yesterday"})
+	is.NoErr(err)
+	is.Equal(late.Due, DateOf(time.Now().AddDate(0, 0, -1)))
+	soon, err := store.Create(CreateTaskParams{Title: "Soon", Due: "+3d"})
+	is.NoErr(err)
+	shipped, err := store.Create(CreateTaskParams{Title: "Shipped", Due: "2025-01-10"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Someday"})
+	is.NoErr(err)
+
+	_, err = store.Create(CreateTaskParams{Title: "Backwards", Start: "+3d", Due: "today"})
+	is.True(errors.Is(err, ErrInvalid)) // starts after it is due
+	_, err = store.Create(CreateTaskParams{Title: "Bad", Due: "soon"})
+	is.True(errors.Is(err, ErrInvalid))
+
+	// edit
+	err = store.Update(&shipped, EditTaskParams{ID: shipped.ID.String(), NewStatus: ptr("done")})
+	is.NoErr(err)
+	err = store.Update(&soon, EditTaskParams{ID: soon.ID.String(), NewStart: ptr("today")})
+	is.NoErr(err)
+	is.Equal(soon.Start, today)
+	is.True(slices.ContainsFunc(soon.History, func(h HistoryEntry) bool { return strings.HasPrefix(h.Change, "Start date changed") }))
+	err = store.Update(&soon, EditTaskParams{ID: soon.ID.String(), NewDue: ptr("yesterday")})
+	is.True(errors.Is(err, ErrInvalid)) // before the start date
+
+	got, err := store.Get(soon.ID.String())
+	is.NoErr(err)
+	is.Equal(got.Start, today)
+	is.Equal(got.Due, DateOf(time.Now().AddDate(0, 0, 3)))
+	is.True(strings.Contains(string(got.Bytes()), "\ndue: "+got.Due.String()+"\n")) // written unquoted
+
+	// list
+	titles := func(params ListTasksParams) []string {
+		res, err := store.List(params)
+		is.NoErr(err)
+		var titles []string
+		for _, t := range res.Tasks {
+			titles = append(titles, t.Title)
+		}
+		return titles
+	}
+	is.Equal(titles(ListTasksParams{Overdue: true}), []string{"Late"}) // not the done task
+	is.Equal(titles(ListTasksParams{DueBefore: "+1w", Sort: []string{"due"}}), []string{"Shipped", "Late", "Soon"})
+	is.Equal(titles(ListTasksParams{Sort: []string{"due"}, Reverse: true}), []string{"Someday", "Soon", "Late", "Shipped"})
+	is.Equal(titles(ListTasksParams{Where: "due>=today"}), []string{"Soon"})
+
+	// removing the due date
+	err = store.Update(&late, EditTaskParams{ID: late.ID.String(), NewDue: ptr("")})
+	is.NoErr(err)
+	is.Equal(titles(ListTasksParams{Overdue: true}), nil)
+}
@@ -0,0 +1,282 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+)
+
+// trashDir is the directory, relative to the tasks directory, holding deleted tasks.
+const trashDir = "trash"
+
+// DeleteTaskParams holds the parameters for deleting a task.
+type DeleteTaskParams struct {
+	ID              string `json:"id"                         jsonschema:"Required. The ID of the task to delete."`
+	Cascade         bool   `json:"cascade,omitempty"          jsonschema:"Also delete the subtasks of the task. Required when the task has subtasks."`
+	StripReferences bool   `json:"strip_references,omitempty" jsonschema:"Remove the deleted tasks from the dependencies of other tasks. Otherwise they are only reported."`
+}
+
+// DeletedTask is a task moved to the trash.
+type DeletedTask struct {
+	Task      Task   `json:"task"`
+	Path      string `json:"path"`       // path of the file before deletion
+	TrashPath string `json:"trash_path"` // path of the file in the trash
+}
+
+// DeleteResult describes the outcome of a delete.
+type DeleteResult struct {
+	Deleted []DeletedTask `json:"deleted"`
+	// References are the remaining tasks that depend on a deleted task.
+	// When references are stripped, they are the tasks that were updated.
+	References []Task `json:"references,omitempty"`
+	Stripped   bool   `json:"stripped,omitempty"`
+}
+
+// Paths returns the files changed by the delete, given the path of a task
+// file: the deleted files, their copies in the trash and the files of the
+// tasks whose references were stripped.
+func (r DeleteResult) Paths(path func(Task) string) []string {
+	var paths []string
+	for _, deleted := range r.Deleted {
+		paths = append(paths, deleted.TrashPath, deleted.Path)
+	}
+	if r.Stripped {
+		for _, ref := range r.References {
+			paths = append(paths, path(ref))
+		}
+	}
+	return paths
+}
+
+// Delete moves a task, and its subtasks when cascading, to the trash directory.
+// Dependencies of other tasks on the deleted tasks are either stripped or
+// reported in the result. Tasks with a running timer are not deleted.
+func (f *FileTaskStore) Delete(params DeleteTaskParams) (DeleteResult, error) {
+	var result DeleteResult
+	id, err := parseTaskID(params.ID)
+	if err != nil {
+		return result, fmt.Errorf("invalid task ID '%s': %w", params.ID, err)
+	}
+	unlock, err := f.lock()
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	task, err := f.findTaskFileIn(id, ".")
+	if err != nil {
+		return result, fmt.Errorf("get task %q: %w", params.ID, err)
+	}
+
+	f.mu.Lock()
+	idx, err := f.index()
+	if err != nil {
+		f.mu.Unlock()
+		return result, err
+	}
+	targets := []indexedTask{task}
+	for _, t := range idx.tasks(f.tasksDir, ".") {
+		if isDescendant(t.Task.ID, task.Task.ID) {
+			targets = append(targets, t)
+		}
+	}
+	f.mu.Unlock()
+	if len(targets) > 1 && !params.Cascade {
+		return result, fmt.Errorf("task %s has %d subtasks, delete them with cascade: %w", id, len(targets)-1, ErrInvalid)
+	}
+	for _, t := range targets {
+		if err := checkNoRunningTimer(t.Task, "delete"); err != nil {
+			return result, err
+		}
+	}
+
+	trash := filepath.Join(f.tasksDir, trashDir)
+	if err := f.fs.MkdirAll(trash, 0o750); err != nil {
+		return result, fmt.Errorf("create trash directory: %w", err)
+	}
+	ids := make([]TaskID, 0, len(targets))
+	for _, t := range targets {
+		trashPath := filepath.Join(trash, filepath.Base(t.Path))
+		if err := f.fs.Rename(t.Path, trashPath); err != nil {
+			return result, fmt.Errorf("move task file: %w", err)
+		}
+		ids = append(ids, t.Task.ID)
+		result.Deleted = append(result.Deleted, DeletedTask{Task: t.Task, Path: t.Path, TrashPath: trashPath})
+	}
+
+	updater := NewReferenceUpdater(f.newConflictDetector(), f)
+	if params.StripReferences {
+		result.Stripped = true
+		if result.References, err = updater.RemoveReferences(ids); err != nil {
+			return result, fmt.Errorf("strip references: %w", err)
+		}
+		return result, nil
+	}
+	for _, id := range ids {
+		refs, err := updater.FindTaskReferences(id)
+		if err != nil {
+			return result, fmt.Errorf("find references: %w", err)
+		}
+		for _, ref := range refs {
+			if !slices.ContainsFunc(result.References, func(t Task) bool { return t.ID.Equals(ref.ID) }) {
+				result.References = append(result.References, ref)
+			}
+		}
+	}
+	return result, nil
+}
+
+// RestoredTask is a task moved back from the trash.
+type RestoredTask struct {
+	Task      Task   `json:"task"`
+	TrashPath string `json:"trash_path"` // path of the file in the trash
+	Path      string `json:"path"`
+}
+
+// RestoreResult lists the restored task followed by its restored subtasks.
+type RestoreResult struct {
+	Restored []RestoredTask `json:"restored"`
+}
+
+// Paths returns the files changed by the restore: the restored files and
+// their former copies in the trash.
+func (r RestoreResult) Paths() []string {
+	var paths []string
+	for _, restored := range r.Restored {
+		paths = append(paths, restored.Path, restored.TrashPath)
+	}
+	return paths
+}
+
+// RestoreFromTrash moves a deleted task, and the subtasks deleted along with it,
+// back to the tasks directory. The parent of the task must not be deleted.
+// If another task took its ID in the meantime, the task is renumbered.
+func (f *FileTaskStore) RestoreFromTrash(id TaskID) (RestoreResult, error) {
+	var result RestoreResult
+	unlock, err := f.lock()
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	deleted, err := f.findTaskFileIn(id, trashDir)
+	if err != nil {
+		return result, fmt.Errorf("get deleted task %q: %w", id, err)
+	}
+	if parent := deleted.Task.Parent; !parent.IsZero() {
+		if _, err := f.findTaskFileIn(parent, "."); err != nil {
+			return result, fmt.Errorf("parent %s of task %s must be restored first: %w", parent, id, err)
+		}
+	}
+
+	f.mu.Lock()
+	idx, err := f.index()
+	if err != nil {
+		f.mu.Unlock()
+		return result, err
+	}
+	var subtasks []indexedTask
+	for _, t := range idx.tasks(f.tasksDir, trashDir) {
+		if isDescendant(t.Task.ID, deleted.Task.ID) {
+			subtasks = append(subtasks, t)
+		}
+	}
+	f.mu.Unlock()
+
+	task, err := f.restore(deleted, trashDir, "restore_id_taken")
+	if err != nil {
+		return result, err
+	}
+	result.Restored = append(result.Restored, RestoredTask{Task: task, TrashPath: deleted.Path, Path: f.Path(task)})
+	if !task.ID.Equals(deleted.Task.ID) {
+		// Subtasks cannot follow a renumbered parent, they stay in the trash.
+		return result, nil
+	}
+	for _, sub := range subtasks {
+		subtask, err := f.restore(sub, trashDir, "restore_id_taken")
+		if err != nil {
+			return result, err
+		}
+		result.Restored = append(result.Restored, RestoredTask{Task: subtask, TrashPath: sub.Path, Path: f.Path(subtask)})
+	}
+	return result, nil
+}
+
+// EmptyTrash permanently removes the deleted tasks and returns their paths.
+func (f *FileTaskStore) EmptyTrash() ([]string, error) {
+	unlock, err := f.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	f.mu.Lock()
+	idx, err := f.index()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	var paths []string
+	for _, rel := range idx.paths() {
+		if filepath.Dir(rel) == trashDir {
+			paths = append(paths, filepath.Join(f.tasksDir, rel))
+		}
+	}
+	f.mu.Unlock()
+
+	for _, path := range paths {
+		if err := f.fs.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove %s: %w", path, err)
+		}
+	}
+	return paths, nil
+}
+
+// restore moves a task stored in dir back to the tasks directory, renumbering
+// it when its ID is taken by an active task.
+func (f *FileTaskStore) restore(found indexedTask, dir, reason string) (Task, error) {
+	_, err := f.findTaskFileIn(found.Task.ID, ".")
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if err := f.fs.Rename(found.Path, f.Path(found.Task)); err != nil {
+			return Task{}, fmt.Errorf("move task file: %w", err)
+		}
+		return found.Task, nil
+	case err != nil:
+		return Task{}, fmt.Errorf("check task ID %q: %w", found.Task.ID, err)
+	}
+
+	// The ID was reused in the meantime, e.g. on another branch.
+	resolver := NewConflictResolver(f.newConflictDetector(), f)
+	newID, err := resolver.findNextAvailableID(found.Task.ID)
+	if err != nil {
+		return Task{}, fmt.Errorf("find available ID for %s: %w", found.Task.ID, err)
+	}
+	plan := &ResolutionPlan{
+		Actions: []ResolutionAction{{
+			Type:        "renumber",
+			OriginalID:  found.Task.ID,
+			NewID:       newID,
+			FilePath:    found.Path,
+			Description: fmt.Sprintf("Renumber restored task %s to %s", found.Task.ID, newID),
+			Metadata:    map[string]any{"reason": reason},
+		}},
+	}
+	if _, err := resolver.executeResolutionPlan(plan, false); err != nil {
+		return Task{}, fmt.Errorf("renumber task %s: %w", found.Task.ID, err)
+	}
+	renumbered, err := f.findTaskFileIn(newID, dir)
+	if err != nil {
+		return Task{}, fmt.Errorf("get renumbered task %s: %w", newID, err)
+	}
+	if err := f.fs.Rename(renumbered.Path, f.Path(renumbered.Task)); err != nil {
+		return Task{}, fmt.Errorf("move task file: %w", err)
+	}
+	return renumbered.Task, nil
+}
+
+// isDescendant reports whether id is a subtask, at any depth, of ancestor.
+func isDescendant(id, ancestor TaskID) bool {
+	return len(id.seg) > len(ancestor.seg) && slices.Equal(id.seg[:len(ancestor.seg)], ancestor.seg)
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestDeleteTask(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	task, err := store.Create(CreateTaskParams{Title: "Mistake"})
+	is.NoErr(err)
+
+	result, err := store.Delete(DeleteTaskParams{ID: "T01"})
+	is.NoErr(err)
+	is.Equal(len(result.Deleted), 1)
+	is.Equal(result.Deleted[0].Path, store.Path(task))
+	is.Equal(result.Deleted[0].TrashPath, filepath.Join(".backlog", "trash", task.FileName()))
+
+	exists, err := afero.Exists(fs, store.Path(task))
+	is.NoErr(err)
+	is.True(!exists)
+	_, err = store.Get("T01")
+	is.True(errors.Is(err, ErrNotFound))
+	listResult, err := store.List(ListTasksParams{Archived: ArchivedInclude})
+	is.NoErr(err)
+	is.Equal(len(listResult.Tasks), 0)
+
+	// The ID stays reserved while the task is in the trash.
+	next, err := store.Create(CreateTaskParams{Title: "Next"})
+	is.NoErr(err)
+	is.Equal(next.ID.String(), "02")
+}
+
+func TestDeleteTask_Subtasks(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Parent"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Child", Parent: "T01"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Grandchild", Parent: "T01.01"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Unrelated"})
+	is.NoErr(err)
+
+	_, err = store.Delete(DeleteTaskParams{ID: "T01"})
+	is.True(errors.Is(err, ErrInvalid)) // subtasks require cascade
+
+	result, err := store.Delete(DeleteTaskParams{ID: "T01", Cascade: true})
+	is.NoErr(err)
+	is.Equal(len(result.Deleted), 3)
+	listResult, err := store.List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(len(listResult.Tasks), 1)
+	is.Equal(listResult.Tasks[0].Title, "Unrelated")
+
+	// Subtasks cannot be restored without their parent.
+	_, err = store.RestoreFromTrash(mustParseTaskID("01.01"))
+	is.True(errors.Is(err, ErrNotFound))
+
+	restored, err := store.RestoreFromTrash(mustParseTaskID("01"))
+	is.NoErr(err)
+	is.Equal(len(restored.Restored), 3) // with its subtasks
+	is.Equal(restored.Restored[0].Task.Title, "Parent")
+	is.Equal(len(restored.Paths()), 6)
+	listResult, err = store.List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(len(listResult.Tasks), 4)
+}
+
+func TestDeleteTask_References(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Dependency"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Other"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Dependent", Dependencies: []string{"T01", "T02"}})
+	is.NoErr(err)
+
+	// References are reported by default
+	result, err := store.Delete(DeleteTaskParams{ID: "T01"})
+	is.NoErr(err)
+	is.True(!result.Stripped)
+	is.Equal(len(result.References), 1)
+	is.Equal(result.References[0].ID.String(), "03")
+	dependent, err := store.Get("T03")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T01", "T02"})
+
+	// and removed on request
+	_, err = store.RestoreFromTrash(mustParseTaskID("01"))
+	is.NoErr(err)
+	result, err = store.Delete(DeleteTaskParams{ID: "T01", StripReferences: true})
+	is.NoErr(err)
+	is.True(result.Stripped)
+	is.Equal(len(result.References), 1)
+	is.Equal(result.References[0].UpdatedAt.Location(), time.UTC)
+	dependent, err = store.Get("T03")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T02"})
+}
+
+func TestEmptyTrash(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Mistake"})
+	is.NoErr(err)
+	result, err := store.Delete(DeleteTaskParams{ID: "T01"})
+	is.NoErr(err)
+
+	paths, err := store.EmptyTrash()
+	is.NoErr(err)
+	is.Equal(paths, []string{result.Deleted[0].TrashPath})
+	exists, err := afero.Exists(fs, result.Deleted[0].TrashPath)
+	is.NoErr(err)
+	is.True(!exists)
+
+	_, err = store.RestoreFromTrash(mustParseTaskID("01"))
+	is.True(errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DependencyCycleError is returned when the dependencies of a task would form a cycle.
+type DependencyCycleError struct {
+	// Cycle lists the tasks of the cycle, starting and ending with the same task.
+	Cycle []TaskID
+}
+
+func (e *DependencyCycleError) Error() string {
+	names := make([]string, len(e.Cycle))
+	for i, id := range e.Cycle {
+		names[i] = id.Name()
+	}
+	return "dependency cycle: " + strings.Join(names, " -> ")
+}
+
+func (e *DependencyCycleError) Unwrap() error { return ErrInvalid }
+
+// dependencyGraph maps a task ID to the IDs of the tasks it depends on.
+type dependencyGraph map[string][]string
+
+func newDependencyGraph(tasks []Task) dependencyGraph {
+	g := make(dependencyGraph, len(tasks))
+	for _, t := range tasks {
+		g[t.ID.String()] = dependencyIDs(t.Dependencies)
+	}
+	return g
+}
+
+// dependencyIDs normalizes the dependencies of a task, invalid IDs are skipped.
+func dependencyIDs(deps []string) []string {
+	ids := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if id, err := parseTaskID(dep); err == nil && !id.IsZero() {
+			ids = append(ids, id.String())
+		}
+	}
+	return ids
+}
+
+// cycleFrom returns a cycle going through the given task, or nil.
+func (g dependencyGraph) cycleFrom(id string) []string {
+	visited := make(map[string]bool)
+	var path []string
+	var visit func(string) bool
+	visit = func(n string) bool {
+		for _, dep := range g[n] {
+			if dep == id {
+				path = append(path, dep)
+				return true
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			path = append(path, dep)
+			if visit(dep) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+	path = append(path, id)
+	if visit(id) {
+		return path
+	}
+	return nil
+}
+
+// cycles returns the dependency cycles of the graph, each one starting with
+// its lowest task ID. Cycles sharing tasks may be reported only once.
+func (g dependencyGraph) cycles() [][]string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int, len(g))
+	var cycles [][]string
+	var stack []string
+	var visit func(string)
+	visit = func(n string) {
+		state[n] = inProgress
+		stack = append(stack, n)
+		for _, dep := range g[n] {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case inProgress:
+				cycle := slices.Clone(stack[slices.Index(stack, dep):])
+				lowest := slices.Index(cycle, slices.MinFunc(cycle, compareIDStrings))
+				cycle = slices.Concat(cycle[lowest:], cycle[:lowest])
+				cycles = append(cycles, append(cycle, cycle[0]))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = done
+	}
+	ids := make([]string, 0, len(g))
+	for id := range g {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, compareIDStrings)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+// compareIDStrings orders normalized task IDs numerically.
+func compareIDStrings(a, b string) int {
+	idA, _ := parseTaskID(a)
+	idB, _ := parseTaskID(b)
+	return compareIDs(idA, idB)
+}
+
+// validateDependencies checks that the dependencies of the task with the given
+// ID do not include the task itself, its ancestors or its subtasks, and do not
+// form a cycle with the dependencies of the other tasks.
+func (f *FileTaskStore) validateDependencies(id TaskID, deps []string) error {
+	for _, dep := range deps {
+		depID, err := parseTaskID(dep)
+		if err != nil {
+			return fmt.Errorf("invalid dependency task ID '%s': %w", dep, err)
+		}
+		switch {
+		case depID.Equals(id):
+			return fmt.Errorf("task %s cannot depend on itself: %w", id.Name(), ErrInvalid)
+		case isDescendant(id, depID):
+			return fmt.Errorf("task %s cannot depend on its parent task %s: %w", id.Name(), depID.Name(), ErrInvalid)
+		case isDescendant(depID, id):
+			return fmt.Errorf("task %s cannot depend on its subtask %s: %w", id.Name(), depID.Name(), ErrInvalid)
+		}
+	}
+
+	f.mu.Lock()
+	idx, err := f.index()
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	files := idx.tasks(f.tasksDir, ".", archivedDir)
+	f.mu.Unlock()
+
+	tasks := make([]Task, len(files))
+	for i, file := range files {
+		tasks[i] = file.Task
+	}
+	g := newDependencyGraph(tasks)
+	g[id.String()] = dependencyIDs(deps)
+	if cycle := g.cycleFrom(id.String()); cycle != nil {
+		err := &DependencyCycleError{Cycle: make([]TaskID, len(cycle))}
+		for i, c := range cycle {
+			err.Cycle[i], _ = parseTaskID(c)
+		}
+		return err
+	}
+	return nil
+}
+
+// dependencyConflicts reports the dependency cycles and the dependencies of
+// active tasks on tasks that are archived, deleted or missing.
+func (cd *ConflictDetector) dependencyConflicts(files []indexedTask) []IDConflict {
+	var conflicts []IDConflict
+	byID := make(map[string]indexedTask, len(files))
+	tasks := make([]Task, 0, len(files))
+	archived := make(map[string]bool)
+	for _, file := range files {
+		id := file.Task.ID.String()
+		if file.Archived {
+			archived[id] = true
+			continue
+		}
+		byID[id] = file
+		tasks = append(tasks, file.Task)
+	}
+	deleted := make(map[string]bool)
+	for _, file := range cd.index.tasks(cd.tasksDir, trashDir) {
+		deleted[file.Task.ID.String()] = true
+	}
+
+	for _, cycle := range newDependencyGraph(tasks).cycles() {
+		conflict := IDConflict{
+			Type:       ConflictTypeDependencyCycle,
+			ConflictID: byID[cycle[0]].Task.ID,
+			DetectedAt: time.Now(),
+		}
+		names := make([]string, len(cycle))
+		for i, id := range cycle {
+			names[i] = byID[id].Task.ID.Name()
+			if i < len(cycle)-1 {
+				conflict.Files = append(conflict.Files, byID[id].Path)
+				conflict.Tasks = append(conflict.Tasks, byID[id].Task)
+			}
+		}
+		conflict.Description = fmt.Sprintf("Dependency cycle: %s", strings.Join(names, " -> "))
+		conflicts = append(conflicts, conflict)
+	}
+
+	for _, task := range tasks {
+		var dangling []TaskID
+		var reasons []string
+		for _, dep := range dependencyIDs(task.Dependencies) {
+			if _, ok := byID[dep]; ok {
+				continue
+			}
+			depID, _ := parseTaskID(dep)
+			dangling = append(dangling, depID)
+			switch {
+			case archived[dep]:
+				reasons = append(reasons, depID.Name()+" is archived")
+			case deleted[dep]:
+				reasons = append(reasons, depID.Name()+" is deleted")
+			default:
+				reasons = append(reasons, depID.Name()+" does not exist")
+			}
+		}
+		if len(dangling) == 0 {
+			continue
+		}
+		conflicts = append(conflicts, IDConflict{
+			Type:         ConflictTypeDanglingDependency,
+			ConflictID:   task.ID,
+			Files:        []string{byID[task.ID.String()].Path},
+			Tasks:        []Task{task},
+			Dependencies: dangling,
+			Description:  fmt.Sprintf("Task %s depends on tasks that are not active: %s", task.ID.String(), strings.Join(reasons, ", ")),
+			DetectedAt:   time.Now(),
+		})
+	}
+	return conflicts
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestDependencyValidation(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "First"},
+		{Title: "Second", Dependencies: []string{"T01"}},
+		{Title: "Third", Dependencies: []string{"T02"}},
+		{Title: "Subtask", Parent: "T03"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+
+	first, err := store.Get("T01")
+	is.NoErr(err)
+	err = store.Update(&first, EditTaskParams{ID: "T01", NewDependencies: []string{"T03"}})
+	var cycleErr *DependencyCycleError
+	is.True(errors.As(err, &cycleErr))
+	is.True(errors.Is(err, ErrInvalid))
+	is.Equal(err.Error(), "dependency cycle: T01 -> T03 -> T02 -> T01")
+
+	first, err = store.Get("T01")
+	is.NoErr(err)
+	err = store.Update(&first, EditTaskParams{ID: "T01", NewDependencies: []string{"T01"}})
+	is.True(errors.Is(err, ErrInvalid)) // itself
+
+	_, err = store.Create(CreateTaskParams{Title: "Subtask", Parent: "T03", Dependencies: []string{"T03"}})
+	is.True(errors.Is(err, ErrInvalid)) // its parent
+	third, err := store.Get("T03")
+	is.NoErr(err)
+	err = store.Update(&third, EditTaskParams{ID: "T03", NewDependencies: []string{"T03.01"}})
+	is.True(errors.Is(err, ErrInvalid)) // its subtask
+
+	first, err = store.Get("T01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&first, EditTaskParams{ID: "T01", NewDependencies: []string{"T03.01"}}))
+}
+
+func TestDetectDependencyConflicts(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "First"},
+		{Title: "Second", Dependencies: []string{"T01"}},
+		{Title: "Archived"},
+		{Title: "Deleted"},
+		{Title: "Dependent", Dependencies: []string{"T02", "T03", "T04"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	// A cycle created on another branch
+	first, err := store.Get("T01")
+	is.NoErr(err)
+	first.Dependencies = MaybeStringArray{"T02"}
+	is.NoErr(store.write(first))
+	_, err = store.Archive(mustParseTaskID("03"))
+	is.NoErr(err)
+	_, err = store.Delete(DeleteTaskParams{ID: "T04"})
+	is.NoErr(err)
+
+	detector := NewConflictDetector(fs, ".backlog")
+	conflicts, err := detector.DetectConflicts()
+	is.NoErr(err)
+	summary := SummarizeConflicts(conflicts)
+	is.Equal(summary.TotalConflicts, 2)
+	is.Equal(summary.DependencyCycles, 1)
+	is.Equal(summary.DanglingDependencies, 1)
+
+	cycle := summary.ConflictsByType[ConflictTypeDependencyCycle][0]
+	is.Equal(cycle.Description, "Dependency cycle: T01 -> T02 -> T01")
+	is.Equal(len(cycle.Tasks), 2)
+	dangling := summary.ConflictsByType[ConflictTypeDanglingDependency][0]
+	is.Equal(dangling.ConflictID.String(), "05")
+	is.Equal(len(dangling.Dependencies), 2)
+	is.True(strings.Contains(dangling.Description, "T03 is archived"))
+	is.True(strings.Contains(dangling.Description, "T04 is deleted"))
+
+	resolver := NewConflictResolver(detector, store)
+	plan, err := resolver.CreateResolutionPlan(conflicts, ResolutionStrategyAutoRenumber)
+	is.NoErr(err)
+	is.Equal(len(plan.Actions), 2)
+	_, err = resolver.ExecuteResolutionPlan(plan, false)
+	is.NoErr(err)
+	dependent, err := store.Get("T05")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T02"})
+}
@@ -0,0 +1,294 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// Duration is the time spent on a task, written like 1h30m.
+type Duration time.Duration
+
+var (
+	_ yaml.Unmarshaler = (*Duration)(nil)
+	_ yaml.Marshaler   = (*Duration)(nil)
+	_ json.Unmarshaler = (*Duration)(nil)
+	_ json.Marshaler   = (*Duration)(nil)
+)
+
+// ParseDuration parses a duration such as 45m, 1h30m or 1.5h.
+func ParseDuration(s string) (Duration, error) {
+	d, err := time.ParseDuration(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if err != nil {
+		return 0, fmt.Errorf("duration %q (want a duration like 45m or 1h30m): %w", s, ErrInvalid)
+	}
+	return Duration(d), nil
+}
+
+// String formats the duration in hours and minutes, such as 1h30m, leaving
+// out the units that are zero.
+func (d Duration) String() string {
+	if d == 0 {
+		return "0m"
+	}
+	s := time.Duration(d).Round(time.Minute).String() // e.g. 1h30m0s
+	s = strings.TrimSuffix(s, "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) { return json.Marshal(d.String()) }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	var err error
+	*d, err = ParseDuration(s)
+	return err
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Duration) MarshalYAML() (any, error) { return d.String(), nil }
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	var err error
+	*d, err = ParseDuration(s)
+	return err
+}
+
+// Effort is the estimate and the time spent of a task and of all its
+// subtasks, recursively. It is computed when tasks are read and never stored.
+type Effort struct {
+	Estimate float64  `json:"estimate"`
+	Spent    Duration `json:"spent"`
+}
+
+// String formats the effort for display, e.g. "8 points, 6h30m spent".
+func (e Effort) String() string {
+	return fmt.Sprintf("%s points, %s spent", formatPoints(e.Estimate), e.Spent)
+}
+
+func formatPoints(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
+}
+
+// parseEstimate returns the estimate of a task in points, which cannot be negative.
+func parseEstimate(p float64) (float64, error) {
+	if p < 0 {
+		return 0, fmt.Errorf("estimate %s cannot be negative: %w", formatPoints(p), ErrInvalid)
+	}
+	return p, nil
+}
+
+// setEffort sets the effort of the given tasks, rolled up over their
+// subtrees among all the tasks. Tasks without estimate nor time spent in
+// their subtree have no effort.
+func setEffort(tasks []Task, all []Task) {
+	children := make(map[string][]Task)
+	for _, t := range all {
+		if !t.Parent.IsZero() {
+			children[t.Parent.String()] = append(children[t.Parent.String()], t)
+		}
+	}
+	var rollup func(t Task, depth int) Effort
+	rollup = func(t Task, depth int) Effort {
+		e := Effort{Estimate: t.Estimate, Spent: t.Spent}
+		if depth > len(all) { // a parent cycle in hand-edited files
+			return e
+		}
+		for _, c := range children[t.ID.String()] {
+			if c.ID.Equals(t.ID) {
+				continue
+			}
+			sub := rollup(c, depth+1)
+			e.Estimate += sub.Estimate
+			e.Spent += sub.Spent
+		}
+		return e
+	}
+	for i := range tasks {
+		if e := rollup(tasks[i], 0); e.Estimate != 0 || e.Spent != 0 {
+			tasks[i].Effort = &e
+		}
+	}
+}
+
+// LogTimeParams holds the parameters for logging time spent on a task.
+type LogTimeParams struct {
+	ID       string `json:"id"             jsonschema:"Required. The ID of the task."`
+	Duration string `json:"duration"       jsonschema:"Required. The time spent, such as 45m or 1h30m."`
+	Note     string `json:"note,omitempty" jsonschema:"What the time was spent on."`
+}
+
+// LogTime adds time spent to a task, recorded in its history with the type
+// "time_log" and the duration in the metadata.
+func (f *FileTaskStore) LogTime(params LogTimeParams) (Task, error) {
+	d, err := ParseDuration(params.Duration)
+	if err != nil {
+		return Task{}, err
+	}
+	if d <= 0 {
+		return Task{}, fmt.Errorf("duration %s must be positive: %w", d, ErrInvalid)
+	}
+	id, err := parseTaskID(params.ID)
+	if err != nil {
+		return Task{}, fmt.Errorf("invalid task ID '%s': %w", params.ID, err)
+	}
+	unlock, err := f.lock()
+	if err != nil {
+		return Task{}, err
+	}
+	defer unlock()
+	found, err := f.findTaskFileIn(id, ".")
+	if err != nil {
+		return Task{}, fmt.Errorf("find task %s: %w", id.Name(), err)
+	}
+	task := found.Task
+	RecordTimeLog(&task, d, params.Note)
+	task.Spent += d
+	task.UpdatedAt = time.Now().UTC()
+	if err := f.writeFile(found.Path, task); err != nil {
+		return Task{}, fmt.Errorf("could not write task file: %w", err)
+	}
+	return task, nil
+}
+
+// RecordTimeLog adds a history entry for time spent on the task.
+func RecordTimeLog(task *Task, d Duration, note string) {
+	change := fmt.Sprintf("Logged %s", d)
+	metadata := map[string]any{"duration": d.String()}
+	if note != "" {
+		change += ": " + note
+		metadata["note"] = note
+	}
+	task.History = append(task.History, HistoryEntry{
+		Timestamp: time.Now().UTC(),
+		Change:    change,
+		Type:      "time_log",
+		Metadata:  metadata,
+	})
+}
+
+// Effort report groupings.
+const (
+	EffortByParent   = "parent"
+	EffortByLabel    = "label"
+	EffortByAssignee = "assignee"
+)
+
+// EffortReportParams holds the parameters of an effort report.
+type EffortReportParams struct {
+	By string `json:"by,omitempty" jsonschema:"How to group the tasks: parent (default), label or assignee."`
+}
+
+// EffortGroup sums the estimates and the time spent of a group of tasks.
+type EffortGroup struct {
+	// Key is the parent ID, label or assignee of the tasks, empty for the
+	// tasks without one.
+	Key string `json:"key"`
+	// Title is the title of the parent task, when grouping by parent.
+	Title    string   `json:"title,omitempty"`
+	Tasks    int      `json:"tasks"`
+	Done     int      `json:"done"`
+	Estimate float64  `json:"estimate"`
+	Spent    Duration `json:"spent"`
+}
+
+func (g *EffortGroup) add(t Task, workflow Workflow) {
+	g.Tasks++
+	if workflow.resolved(t.Status) {
+		g.Done++
+	}
+	g.Estimate += t.Estimate
+	g.Spent += t.Spent
+}
+
+// EffortReport is the effort of the active tasks grouped by parent, label or
+// assignee.
+type EffortReport struct {
+	By     string        `json:"by"`
+	Groups []EffortGroup `json:"groups"`
+	// Total counts every task once, even if it is in several groups.
+	Total EffortGroup `json:"total"`
+}
+
+// EffortReport sums the estimates and the time spent of the active tasks by
+// group. Grouping by parent, each task is counted under its direct parent; by
+// label or assignee, a task is counted under each of its labels or assignees.
+// The group of the tasks without parent, label or assignee comes last.
+func (f *FileTaskStore) EffortReport(params EffortReportParams) (EffortReport, error) {
+	by := strings.ToLower(strings.TrimSpace(params.By))
+	if by == "" {
+		by = EffortByParent
+	}
+	var keys func(Task) []string
+	switch by {
+	case EffortByParent:
+		keys = func(t Task) []string { return []string{t.Parent.String()} }
+	case EffortByLabel, "labels":
+		by, keys = EffortByLabel, func(t Task) []string { return t.Labels }
+	case EffortByAssignee, "assigned":
+		by, keys = EffortByAssignee, func(t Task) []string { return t.Assigned }
+	default:
+		return EffortReport{}, fmt.Errorf("group by %q (want %q, %q or %q): %w", params.By, EffortByParent, EffortByLabel, EffortByAssignee, ErrInvalid)
+	}
+	tasks, err := f.loadAll(".")
+	if err != nil {
+		return EffortReport{}, fmt.Errorf("loading tasks: %v", err)
+	}
+	titles := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID.String()] = t.Title
+	}
+
+	report := EffortReport{By: by, Groups: []EffortGroup{}}
+	groups := make(map[string]*EffortGroup)
+	for _, t := range tasks {
+		report.Total.add(t, f.workflow)
+		taskKeys := keys(t)
+		if len(taskKeys) == 0 {
+			taskKeys = []string{""}
+		}
+		for _, k := range taskKeys {
+			k = strings.TrimSpace(k)
+			g, ok := groups[k]
+			if !ok {
+				g = &EffortGroup{Key: k}
+				if by == EffortByParent {
+					g.Title = titles[k]
+				}
+				groups[k] = g
+			}
+			g.add(t, f.workflow)
+		}
+	}
+	for _, g := range groups {
+		report.Groups = append(report.Groups, *g)
+	}
+	slices.SortFunc(report.Groups, func(a, b EffortGroup) int {
+		if a.Key == "" || b.Key == "" {
+			return strings.Compare(b.Key, a.Key) // the group without a key last
+		}
+		if by == EffortByParent {
+			return compareIDStrings(a.Key, b.Key)
+		}
+		return strings.Compare(strings.ToLower(a.Key), strings.ToLower(b.Key))
+	})
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestDuration(t *testing.T) {
+	is := is.New(t)
+	for in, want := range map[string]string{
+		"1h30m":  "1h30m",
+		"90m":    "1h30m",
+		"1.5h":   "1h30m",
+		"2h":     "2h",
+		"45m":    "45m",
+		"1h 5m":  "1h5m",
+		"0s":     "0m",
+		"25h15m": "25h15m",
+	} {
+		d, err := ParseDuration(in)
+		is.NoErr(err)
+		is.Equal(d.String(), want) // formatted duration
+	}
+	for _, in := range []string{"", "1d", "an hour"} {
+		_, err := ParseDuration(in)
+		is.True(errors.Is(err, ErrInvalid)) // invalid duration
+	}
+}
+
+func TestLogTimeAndEffort(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+
+	release, err := store.Create(CreateTaskParams{Title: "Release", Labels: []string{"release"}, Estimate: 2})
+	is.NoErr(err)
+	notes, err := store.Create(CreateTaskParams{Title: "Notes", Parent: "T01", Labels: []string{"release", "docs"}, Assigned: []string{"alice"}, Estimate: 1.5})
+	is.NoErr(err)
+	tag, err := store.Create(CreateTaskParams{Title: "Tag", Parent: "T01.01", Estimate: 0.5})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Unplanned"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Negative", Estimate: -1})
+	is.True(errors.Is(err, ErrInvalid))
+
+	logged, err := store.LogTime(LogTimeParams{ID: notes.ID.String(), Duration: "1h30m", Note: "First draft"})
+	is.NoErr(err)
+	is.Equal(logged.Spent, Duration(90*time.Minute))
+	last := logged.History[len(logged.History)-1]
+	is.Equal(last.Type, "time_log")
+	is.Equal(last.Change, "Logged 1h30m: First draft")
+	is.Equal(last.Metadata["duration"], "1h30m")
+	_, err = store.LogTime(LogTimeParams{ID: notes.ID.String(), Duration: "30m"})
+	is.NoErr(err)
+	_, err = store.LogTime(LogTimeParams{ID: tag.ID.String(), Duration: "15m"})
+	is.NoErr(err)
+	_, err = store.LogTime(LogTimeParams{ID: tag.ID.String(), Duration: "-1h"})
+	is.True(errors.Is(err, ErrInvalid)) // not positive
+	_, err = store.LogTime(LogTimeParams{ID: "T09", Duration: "1h"})
+	is.True(errors.Is(err, ErrNotFound))
+
+	// rollups over the subtrees
+	got, err := store.Get(release.ID.String())
+	is.NoErr(err)
+	is.Equal(got.Spent, Duration(0))
+	is.Equal(*got.Effort, Effort{Estimate: 4, Spent: Duration(2*time.Hour + 15*time.Minute)})
+	is.True(strings.Contains(string(got.Bytes()), "\nestimate: 2\n"))
+	got, err = store.Get(notes.ID.String())
+	is.NoErr(err)
+	is.Equal(got.Spent, Duration(2*time.Hour))
+	is.True(strings.Contains(string(got.Bytes()), "\nspent: 2h\n"))
+	res, err := store.List(ListTasksParams{Where: "title:unplanned"})
+	is.NoErr(err)
+	is.Equal(res.Tasks[0].Effort, nil) // no estimate nor time spent
+
+	// edit
+	err = store.Update(&tag, EditTaskParams{ID: tag.ID.String(), NewEstimate: ptr(1.0), NewSpent: ptr("45m")})
+	is.NoErr(err)
+	is.Equal(tag.Spent, Duration(45*time.Minute))
+	err = store.Update(&tag, EditTaskParams{ID: tag.ID.String(), NewSpent: ptr("soon")})
+	is.True(errors.Is(err, ErrInvalid))
+
+	// report
+	report, err := store.EffortReport(EffortReportParams{})
+	is.NoErr(err)
+	is.Equal(report.By, EffortByParent)
+	is.Equal(report.Groups, []EffortGroup{
+		{Key: "01", Title: "Release", Tasks: 1, Estimate: 1.5, Spent: Duration(2 * time.Hour)},
+		{Key: "01.01", Title: "Notes", Tasks: 1, Estimate: 1, Spent: Duration(45 * time.Minute)},
+		{Key: "", Tasks: 2, Estimate: 2}, // top-level tasks
+	})
+	is.Equal(report.Total, EffortGroup{Tasks: 4, Estimate: 4.5, Spent: Duration(2*time.Hour + 45*time.Minute)})
+
+	report, err = store.EffortReport(EffortReportParams{By: "label"})
+	is.NoErr(err)
+	is.Equal(len(report.Groups), 3)
+	is.Equal(report.Groups[0], EffortGroup{Key: "docs", Tasks: 1, Estimate: 1.5, Spent: Duration(2 * time.Hour)})
+	is.Equal(report.Groups[1], EffortGroup{Key: "release", Tasks: 2, Estimate: 3.5, Spent: Duration(2 * time.Hour)})
+	is.Equal(report.Groups[2].Key, "") // without a label, last
+	is.Equal(report.Total.Tasks, 4)    // counted once
+
+	_, err = store.EffortReport(EffortReportParams{By: "sprint"})
+	is.True(errors.Is(err, ErrInvalid))
+}
@@ -0,0 +1,387 @@
+package core
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// FieldType is the type of the values of a custom field.
+type FieldType string
+
+const (
+	FieldString FieldType = "string"
+	FieldInt    FieldType = "int"
+	FieldEnum   FieldType = "enum"
+	FieldDate   FieldType = "date" // YYYY-MM-DD
+	FieldList   FieldType = "list" // of strings
+)
+
+var fieldTypes = []FieldType{FieldString, FieldInt, FieldEnum, FieldDate, FieldList}
+
+// FieldDef declares a custom field of the tasks, stored in their front matter
+// next to the built-in fields. Custom fields are declared under the fields key
+// of the repository config:
+//
+//	fields:
+//	  component:
+//	    type: enum
+//	    values: [api, cli, ui]
+//	  sprint:
+//	    type: int
+//	    description: Sprint the task is planned for
+type FieldDef struct {
+	Name        string    `json:"name"`
+	Type        FieldType `json:"type"`
+	Values      []string  `json:"values,omitempty"` // of an enum
+	Description string    `json:"description,omitempty"`
+}
+
+// Field names are usable in where queries, which only allow letters and '_' in field names.
+var fieldNameRegex = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// reservedFieldNames are the keys of the front matter and the sort fields,
+// the where fields being checked separately.
+var reservedFieldNames = []string{
+	"id", "title", "status", "assignee", "labels", "dependencies", "parent",
+	"priority", "start", "due", "estimate", "spent", "created_at", "updated_at", "history", "created", "updated",
+}
+
+// ParseFieldDefs returns the custom fields declared in a config, by name,
+// sorted by name.
+func ParseFieldDefs(config map[string]any) ([]FieldDef, error) {
+	defs := make([]FieldDef, 0, len(config))
+	for name, value := range config {
+		def, err := parseFieldDef(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		defs = append(defs, def)
+	}
+	slices.SortFunc(defs, func(a, b FieldDef) int { return strings.Compare(a.Name, b.Name) })
+	return defs, nil
+}
+
+func parseFieldDef(name string, value any) (FieldDef, error) {
+	if !fieldNameRegex.MatchString(name) {
+		return FieldDef{}, fmt.Errorf("name must only contain lower case letters and '_': %w", ErrInvalid)
+	}
+	if _, ok := whereFields[name]; ok || slices.Contains(reservedFieldNames, name) {
+		return FieldDef{}, fmt.Errorf("name is reserved for a built-in field: %w", ErrInvalid)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return FieldDef{}, fmt.Errorf("%v: %w", err, ErrInvalid)
+	}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.DisallowUnknownFields()
+	var def FieldDef
+	if err := dec.Decode(&def); err != nil {
+		return FieldDef{}, fmt.Errorf("%v: %w", err, ErrInvalid)
+	}
+	def.Name = name
+	if !slices.Contains(fieldTypes, def.Type) {
+		return FieldDef{}, fmt.Errorf("unknown type %q (want one of %q): %w", def.Type, fieldTypes, ErrInvalid)
+	}
+	if (def.Type == FieldEnum) != (len(def.Values) > 0) {
+		return FieldDef{}, fmt.Errorf("values are required for, and only allowed for, an enum: %w", ErrInvalid)
+	}
+	return def, nil
+}
+
+// lookupField returns the custom field with the given name.
+func lookupField(defs []FieldDef, name string) (FieldDef, bool) {
+	i := slices.IndexFunc(defs, func(d FieldDef) bool { return d.Name == name })
+	if i < 0 {
+		return FieldDef{}, false
+	}
+	return defs[i], true
+}
+
+// Parse converts a value given by a user, often a string from the command
+// line, to the value stored in the front matter: a string, an int or a list of
+// strings. A nil or empty value is returned as nil, meaning no value.
+func (d FieldDef) Parse(value any) (any, error) {
+	if value == nil || value == "" {
+		return nil, nil
+	}
+	if d.Type == FieldList {
+		list := fieldList(value)
+		if len(list) == 0 {
+			return nil, nil
+		}
+		return list, nil
+	}
+	s, ok := fieldScalar(value)
+	if !ok {
+		return nil, fmt.Errorf("field %s expects a single value, got %v: %w", d.Name, value, ErrInvalid)
+	}
+	switch d.Type {
+	case FieldInt:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("field %s expects an integer, got %q: %w", d.Name, s, ErrInvalid)
+		}
+		return n, nil
+	case FieldEnum:
+		i := slices.IndexFunc(d.Values, func(v string) bool { return strings.EqualFold(v, s) })
+		if i < 0 {
+			return nil, fmt.Errorf("field %s expects one of %q, got %q: %w", d.Name, d.Values, s, ErrInvalid)
+		}
+		return d.Values[i], nil
+	case FieldDate:
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return nil, fmt.Errorf("field %s expects a date (YYYY-MM-DD), got %q: %w", d.Name, s, ErrInvalid)
+		}
+		return s, nil
+	default:
+		return s, nil
+	}
+}
+
+// fieldScalar formats a single value, reporting false for lists and maps.
+func fieldScalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case float64: // numbers decoded from JSON
+		if v == math.Trunc(v) {
+			return strconv.FormatInt(int64(v), 10), true
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int, int64, bool:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
+
+// fieldList returns the strings of a list, or of a comma separated string.
+func fieldList(value any) []string {
+	var items []any
+	switch v := value.(type) {
+	case []any:
+		items = v
+	case []string:
+		for _, s := range v {
+			items = append(items, s)
+		}
+	case string:
+		for s := range strings.SplitSeq(v, ",") {
+			items = append(items, s)
+		}
+	default:
+		items = []any{v}
+	}
+	list := []string{}
+	for _, item := range items {
+		if s, ok := fieldScalar(item); ok && s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// fieldNumber returns the value of a custom field as a number.
+func fieldNumber(value any) (float64, bool) {
+	s, ok := fieldScalar(value)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
+
+// normalizeFieldValue converts the dates and times decoded from the front
+// matter to strings so that the values of custom fields survive the JSON of
+// the index and the MCP tools, see fieldsNode for the way back.
+func normalizeFieldValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		if v.Equal(v.Truncate(24*time.Hour)) && v.Location() == time.UTC {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339Nano)
+	case []any:
+		for i := range v {
+			v[i] = normalizeFieldValue(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = normalizeFieldValue(v[k])
+		}
+	}
+	return value
+}
+
+// fieldsNode encodes the custom fields as the entries of a YAML mapping. In
+// the given date fields, the strings holding a date or a time are written as
+// YAML timestamps, the way they were read. Other strings stay strings even if
+// they look like a date.
+func fieldsNode(fields map[string]any, dateFields []string) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(fields); err != nil {
+		return nil, err
+	}
+	var tag func(n *yaml.Node)
+	tag = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && isTimestamp(n.Value) {
+			n.Tag = "!!timestamp"
+			n.Style = 0
+		}
+		for _, c := range n.Content {
+			tag(c)
+		}
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if slices.Contains(dateFields, node.Content[i].Value) {
+			tag(node.Content[i+1])
+		}
+	}
+	return &node, nil
+}
+
+// hasTimestamp reports whether a value decoded from the front matter holds a
+// date or a time.
+func hasTimestamp(value any) bool {
+	switch v := value.(type) {
+	case time.Time:
+		return true
+	case []any:
+		return slices.ContainsFunc(v, hasTimestamp)
+	case map[string]any:
+		for _, item := range v {
+			if hasTimestamp(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setDateFields sets the date fields of the task: its declared date fields,
+// and its undeclared fields holding timestamps when read.
+func (f *FileTaskStore) setDateFields(task *Task) {
+	var dateFields []string
+	for name := range task.Fields {
+		def, declared := lookupField(f.fields, name)
+		if declared && def.Type == FieldDate || !declared && slices.Contains(task.dateFields, name) {
+			dateFields = append(dateFields, name)
+		}
+	}
+	task.dateFields = dateFields
+}
+
+func isTimestamp(s string) bool {
+	if _, err := time.Parse(time.DateOnly, s); err == nil {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339Nano, s)
+	return err == nil
+}
+
+// setFields sets the values of declared custom fields, a nil value removing
+// the field. The changes are recorded in the history if record is set.
+func (f *FileTaskStore) setFields(task *Task, values map[string]any, record bool) error {
+	names := slices.Sorted(maps.Keys(values))
+	for _, name := range names {
+		def, ok := lookupField(f.fields, name)
+		if !ok {
+			return fmt.Errorf("unknown field %q (want one of %q): %w", name, fieldNames(f.fields), ErrInvalid)
+		}
+		value, err := def.Parse(values[name])
+		if err != nil {
+			return err
+		}
+		old, exists := task.Fields[name]
+		switch {
+		case value == nil && !exists:
+			continue
+		case value == nil:
+			delete(task.Fields, name)
+			if record {
+				RecordChange(task, fmt.Sprintf("Field %s removed", name))
+			}
+		case exists && sameFieldValue(old, value):
+		default:
+			if task.Fields == nil {
+				task.Fields = make(map[string]any)
+			}
+			task.Fields[name] = value
+			if record {
+				RecordChange(task, fmt.Sprintf("Field %s changed from %q to %q", name, formatFieldValue(old), formatFieldValue(value)))
+			}
+		}
+	}
+	if len(task.Fields) == 0 {
+		task.Fields = nil
+	}
+	return nil
+}
+
+// sameFieldValue compares values of custom fields, which may have been read
+// with another type from a task file or the index, such as a float for an int.
+func sameFieldValue(a, b any) bool {
+	if sa, ok := fieldScalar(a); ok {
+		sb, ok := fieldScalar(b)
+		return ok && sa == sb
+	}
+	return slices.Equal(fieldList(a), fieldList(b))
+}
+
+func fieldNames(defs []FieldDef) []string {
+	names := make([]string, len(defs))
+	for i, d := range defs {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// formatFieldValue formats a value of a custom field, lists joined with commas.
+func formatFieldValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := fieldScalar(value); ok {
+		return s
+	}
+	return strings.Join(fieldList(value), ",")
+}
+
+// compareFieldValues compares values of a custom field: ints as numbers,
+// enums in the order of their values and the others as text. Missing values
+// come last.
+func compareFieldValues(def FieldDef, a, b any) int {
+	if a == nil || b == nil {
+		return cmp.Compare(boolInt(a == nil), boolInt(b == nil))
+	}
+	switch def.Type {
+	case FieldInt:
+		na, _ := fieldNumber(a)
+		nb, _ := fieldNumber(b)
+		return cmp.Compare(na, nb)
+	case FieldEnum:
+		index := func(v any) int {
+			return slices.IndexFunc(def.Values, func(s string) bool { return strings.EqualFold(s, formatFieldValue(v)) })
+		}
+		return cmp.Compare(index(a), index(b))
+	default:
+		return strings.Compare(strings.ToLower(formatFieldValue(a)), strings.ToLower(formatFieldValue(b)))
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
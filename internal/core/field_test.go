@@ -0,0 +1,194 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func testFieldDefs(t *testing.T) []FieldDef {
+	t.Helper()
+	defs, err := ParseFieldDefs(map[string]any{
+		"component": map[string]any{"type": "enum", "values": []any{"api", "cli", "ui"}},
+		"sprint":    map[string]any{"type": "int", "description": "Sprint the task is planned for"},
+		"customer":  map[string]any{"type": "list"},
+		"shipped":   map[string]any{"type": "date"},
+		"team":      map[string]any{"type": "string"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return defs
+}
+
+func TestParseFieldDefs(t *testing.T) {
+	is := is.New(t)
+	defs := testFieldDefs(t)
+	is.Equal(fieldNames(defs), []string{"component", "customer", "shipped", "sprint", "team"}) // sorted by name
+	is.Equal(defs[3], FieldDef{Name: "sprint", Type: FieldInt, Description: "Sprint the task is planned for"})
+
+	for name, def := range map[string]any{
+		"status":    map[string]any{"type": "string"},                       // front matter key
+		"label":     map[string]any{"type": "string"},                       // where field
+		"story-pts": map[string]any{"type": "int"},                          // not usable in where queries
+		"size":      map[string]any{"type": "float"},                        // unknown type
+		"kind":      map[string]any{"type": "enum"},                         // enum without values
+		"area":      map[string]any{"type": "string", "values": []any{"a"}}, // values of a non enum
+		"owner":     map[string]any{"type": "string", "default": "someone"}, // unknown key
+	} {
+		_, err := ParseFieldDefs(map[string]any{name: def})
+		is.True(errors.Is(err, ErrInvalid)) // invalid definition
+	}
+}
+
+func TestFieldDefParse(t *testing.T) {
+	is := is.New(t)
+	defs := testFieldDefs(t)
+	get := func(name string) FieldDef {
+		def, ok := lookupField(defs, name)
+		is.True(ok)
+		return def
+	}
+
+	for _, tc := range []struct {
+		field string
+		value any
+		want  any
+	}{
+		{"component", "API", "api"},
+		{"sprint", "12", 12},
+		{"sprint", float64(3), 3},
+		{"customer", "acme, globex", []string{"acme", "globex"}},
+		{"customer", []any{"acme"}, []string{"acme"}},
+		{"shipped", "2025-10-01", "2025-10-01"},
+		{"team", " core ", "core"},
+		{"team", "", nil},
+		{"customer", nil, nil},
+	} {
+		got, err := get(tc.field).Parse(tc.value)
+		is.NoErr(err)
+		is.Equal(got, tc.want) // value of tc.field
+	}
+
+	for field, value := range map[string]any{
+		"component": "db",
+		"sprint":    "next",
+		"shipped":   "tomorrow",
+		"team":      []any{"a", "b"},
+	} {
+		_, err := get(field).Parse(value)
+		is.True(errors.Is(err, ErrInvalid)) // invalid value
+	}
+}
+
+func TestCustomFieldsRoundTrip(t *testing.T) {
+	is := is.New(t)
+	content := `---
+id: "1"
+title: Keep custom fields
+status: todo
+created_at: 2025-09-01T10:00:00Z
+sprint: 3
+shipped: 2025-10-01
+reviewed_at: 2025-09-02T08:30:00Z
+customer:
+    - acme
+extra:
+    nested: true
+history:
+    - timestamp: 2025-09-01T10:00:00Z
+      change: Task created
+---
+## Description
+
+`
+	task, err := parseTask([]byte(content))
+	is.NoErr(err)
+	is.Equal(task.Fields["sprint"], 3)
+	is.Equal(task.Fields["shipped"], "2025-10-01")
+	is.Equal(task.Fields["reviewed_at"], "2025-09-02T08:30:00Z")
+
+	out := string(task.Bytes())
+	is.True(strings.Contains(out, "\nshipped: 2025-10-01\n"))                       // dates are written unquoted
+	is.True(strings.Contains(out, "\nreviewed_at: 2025-09-02T08:30:00Z\n"))         // and so are times
+	is.True(strings.Index(out, "\nsprint: 3\n") < strings.Index(out, "\nhistory:")) // before the history
+
+	again, err := parseTask([]byte(out))
+	is.NoErr(err)
+	is.Equal(again.Fields, task.Fields)
+	is.Equal(string(again.Bytes()), out)
+}
+
+func TestCustomFields(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog", WithFields(testFieldDefs(t)))
+
+	api, err := store.Create(CreateTaskParams{Title: "API", Fields: map[string]any{"component": "api", "sprint": "2"}})
+	is.NoErr(err)
+	is.Equal(api.Fields, map[string]any{"component": "api", "sprint": 2})
+	ui, err := store.Create(CreateTaskParams{Title: "UI", Fields: map[string]any{"component": "ui", "sprint": "1", "customer": "acme,globex"}})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "None"})
+	is.NoErr(err)
+
+	_, err = store.Create(CreateTaskParams{Title: "Bad", Fields: map[string]any{"estimate": "3"}})
+	is.True(errors.Is(err, ErrInvalid)) // undeclared field
+
+	// edit
+	err = store.Update(&ui, EditTaskParams{ID: ui.ID.String(), SetFields: map[string]any{"sprint": nil, "shipped": "2025-10-01"}})
+	is.NoErr(err)
+	got, err := store.Get(ui.ID.String())
+	is.NoErr(err)
+	is.Equal(got.Fields, map[string]any{"component": "ui", "customer": []any{"acme", "globex"}, "shipped": "2025-10-01"})
+	is.True(slices.ContainsFunc(got.History, func(h HistoryEntry) bool { return h.Change == "Field sprint removed" })) // recorded
+
+	// where and sort
+	titles := func(where string, sort ...string) []string {
+		res, err := store.List(ListTasksParams{Where: where, Sort: sort})
+		is.NoErr(err)
+		var titles []string
+		for _, t := range res.Tasks {
+			titles = append(titles, t.Title)
+		}
+		return titles
+	}
+	is.Equal(titles("component:api"), []string{"API"})
+	is.Equal(titles("component>=cli"), []string{"UI"}) // in the order of the values
+	is.Equal(titles("sprint>=2"), []string{"API"})
+	is.Equal(titles("sprint="), []string{"UI", "None"}) // no sprint
+	is.Equal(titles("customer:globex"), []string{"UI"})
+	is.Equal(titles("shipped<2025-10-02"), []string{"UI"})
+	is.Equal(titles("", "component"), []string{"API", "UI", "None"}) // missing values last
+
+	_, err = store.List(ListTasksParams{Where: "component:db"})
+	is.True(errors.Is(err, ErrInvalid)) // not a value of the enum
+}
+
+func TestCustomFieldsDates(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog", WithFields(testFieldDefs(t)))
+
+	task, err := store.Create(CreateTaskParams{Title: "Dates", Fields: map[string]any{
+		"team":     "2025-10-01",
+		"customer": "2025-10-02,acme",
+		"shipped":  "2025-10-03",
+	}})
+	is.NoErr(err)
+	b, err := afero.ReadFile(fs, store.Path(task))
+	is.NoErr(err)
+	out := string(b)
+	is.True(strings.Contains(out, "\nteam: \"2025-10-01\"\n")) // strings looking like a date stay strings
+	is.True(strings.Contains(out, "\n    - \"2025-10-02\"\n")) // and so do the items of lists
+	is.True(strings.Contains(out, "\nshipped: 2025-10-03\n"))  // declared dates are written unquoted
+
+	got, err := store.Get(task.ID.String())
+	is.NoErr(err)
+	is.Equal(got.Fields["team"], "2025-10-01")
+	is.Equal(got.Fields["customer"], []any{"2025-10-02", "acme"})
+	is.Equal(got.Fields["shipped"], "2025-10-03")
+}
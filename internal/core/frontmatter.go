@@ -15,9 +15,17 @@ type Frontmatter struct {
 	Dependencies MaybeStringArray `yaml:"dependencies,omitempty"`
 	Parent       string           `yaml:"parent,omitempty"`
 	Priority     string           `yaml:"priority,omitempty"`
+	Start        Date             `yaml:"start,omitempty"`
+	Due          Date             `yaml:"due,omitempty"`
+	Estimate     float64          `yaml:"estimate,omitempty"`
+	Spent        Duration         `yaml:"spent,omitempty"`
 	CreatedAt    time.Time        `yaml:"created_at"`
 	UpdatedAt    time.Time        `yaml:"updated_at,omitempty"`
 	History      []HistoryEntry   `yaml:"history,omitempty"`
+	// Fields holds the other keys, the custom fields, see FieldDef.
+	Fields map[string]any `yaml:",inline"`
+
+	keys []string // in the order they were read
 }
 
 func parseFrontMatter(content []byte) (*Frontmatter, error) {
@@ -26,5 +34,15 @@ func parseFrontMatter(content []byte) (*Frontmatter, error) {
 	if err != nil {
 		return nil, err
 	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.MappingNode {
+		mapping := node.Content[0].Content
+		for i := 0; i < len(mapping); i += 2 {
+			matter.keys = append(matter.keys, mapping[i].Value)
+		}
+	}
 	return &matter, nil
 }
@@ -2,8 +2,6 @@ package core
 
 import (
 	"fmt"
-
-	"github.com/spf13/afero"
 )
 
 // Get implements TaskStore.
@@ -13,19 +11,16 @@ func (f *FileTaskStore) Get(id string) (task Task, err error) {
 		return task, fmt.Errorf("invalid task ID '%s': %w", id, err)
 	}
 
-	filePath, err := f.findTaskFileByID(taskID)
+	found, err := f.findTaskFileByID(taskID)
 	if err != nil {
 		return task, fmt.Errorf("find task file: %w", err)
 	}
-	b, err := afero.ReadFile(f.fs, filePath)
+	task = found.Task
+	active, err := f.loadAll(".")
 	if err != nil {
-		return task, err
+		return task, fmt.Errorf("loading tasks: %v", err)
 	}
-
-	task, err = parseTask(b)
-	if err != nil {
-		return task, fmt.Errorf("parse task %s: %v", filePath, err)
-	}
-
-	return task, nil
+	tasks := []Task{task}
+	setProgress(tasks, active, f.workflow)
+	return tasks[0], nil
 }
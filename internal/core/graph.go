@@ -0,0 +1,222 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// GraphFormat is the output format of a task graph.
+type GraphFormat string
+
+const (
+	GraphFormatDOT     GraphFormat = "dot"     // Graphviz
+	GraphFormatMermaid GraphFormat = "mermaid" // Mermaid flowchart
+)
+
+// GraphParams holds the parameters for rendering a task graph.
+type GraphParams struct {
+	// Root restricts the graph to a task and its subtasks, empty for all the tasks.
+	Root   string
+	Format GraphFormat
+}
+
+// statusColors are the fill colors of the nodes by status.
+var statusColors = map[Status]string{
+	StatusTodo:       "#f2f2f2",
+	StatusInProgress: "#fff2a8",
+	StatusDone:       "#b7e4c7",
+	StatusCancelled:  "#d9d9d9",
+	StatusArchived:   "#d9d9d9",
+	StatusRejected:   "#f4b6b6",
+}
+
+// graphNode is a task drawn in the graph. External nodes are dependencies
+// outside of the rendered subtree, drawn for context.
+type graphNode struct {
+	task     Task
+	external bool
+}
+
+// graphEdge goes from a parent to its subtask, or from a dependency to the task depending on it.
+type graphEdge struct {
+	from, to TaskID
+	subtask  bool
+}
+
+// WriteGraph renders the parent and dependency relationships between the
+// tasks, or between the tasks of a subtree, in the given format. Nodes are
+// colored by status and their border is styled by priority.
+func WriteGraph(w io.Writer, tasks []Task, params GraphParams) error {
+	var root TaskID
+	if params.Root != "" {
+		var err error
+		if root, err = parseTaskID(params.Root); err != nil {
+			return fmt.Errorf("invalid root task ID '%s': %w", params.Root, err)
+		}
+	}
+	nodes, edges, err := taskGraph(tasks, root)
+	if err != nil {
+		return err
+	}
+	switch params.Format {
+	case GraphFormatDOT:
+		return writeDOT(w, nodes, edges)
+	case "", GraphFormatMermaid:
+		return writeMermaid(w, nodes, edges)
+	default:
+		return fmt.Errorf("graph format %q (want %q or %q): %w", params.Format, GraphFormatDOT, GraphFormatMermaid, ErrInvalid)
+	}
+}
+
+// taskGraph selects the nodes and edges of the graph, sorted by ID.
+func taskGraph(tasks []Task, root TaskID) ([]graphNode, []graphEdge, error) {
+	byID := make(map[string]Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID.String()] = t
+	}
+	if !root.IsZero() {
+		if _, ok := byID[root.String()]; !ok {
+			return nil, nil, fmt.Errorf("root task %s: %w", root.Name(), ErrNotFound)
+		}
+	}
+
+	var nodes []graphNode
+	var edges []graphEdge
+	inScope := make(map[string]bool)
+	for _, t := range tasks {
+		if root.IsZero() || t.ID.Equals(root) || isDescendant(t.ID, root) {
+			nodes = append(nodes, graphNode{task: t})
+			inScope[t.ID.String()] = true
+		}
+	}
+	external := make(map[string]bool)
+	for _, n := range slices.Clone(nodes) {
+		t := n.task
+		if !t.Parent.IsZero() && inScope[t.Parent.String()] {
+			edges = append(edges, graphEdge{from: t.Parent, to: t.ID, subtask: true})
+		}
+		for _, dep := range dependencyIDs(t.Dependencies) {
+			depTask, ok := byID[dep]
+			if !ok {
+				continue // dangling dependency
+			}
+			if !inScope[dep] && !external[dep] {
+				nodes = append(nodes, graphNode{task: depTask, external: true})
+				external[dep] = true
+			}
+			edges = append(edges, graphEdge{from: depTask.ID, to: t.ID})
+		}
+	}
+	slices.SortFunc(nodes, func(a, b graphNode) int { return compareIDs(a.task.ID, b.task.ID) })
+	return nodes, edges, nil
+}
+
+func writeDOT(w io.Writer, nodes []graphNode, edges []graphEdge) error {
+	var b strings.Builder
+	b.WriteString("digraph backlog {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	for _, n := range nodes {
+		t := n.task
+		attrs := []string{
+			fmt.Sprintf("label=%s", dotQuote(t.ID.Name()+"\n"+t.Title)),
+			fmt.Sprintf("fillcolor=%q", statusColor(t.Status)),
+			fmt.Sprintf("tooltip=%s", dotQuote(fmt.Sprintf("%s, %s priority", t.Status, t.Priority))),
+		}
+		switch t.Priority {
+		case PriorityCritical:
+			attrs = append(attrs, `color="#c0392b"`, "penwidth=3")
+		case PriorityHigh:
+			attrs = append(attrs, `color="#e67e22"`, "penwidth=2")
+		case PriorityLow:
+			attrs = append(attrs, `color="#7f8c8d"`)
+		}
+		if n.external {
+			attrs = append(attrs, `style="rounded,filled,dashed"`)
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", t.ID.Name(), strings.Join(attrs, ", "))
+	}
+	for _, e := range edges {
+		style := ""
+		if e.subtask {
+			style = " [style=dashed, arrowhead=none]"
+		}
+		fmt.Fprintf(&b, "  %q -> %q%s;\n", e.from.Name(), e.to.Name(), style)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMermaid(w io.Writer, nodes []graphNode, edges []graphEdge) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	byStatus := make(map[Status][]string)
+	var statuses []Status
+	for _, n := range nodes {
+		t := n.task
+		id := mermaidID(t.ID)
+		fmt.Fprintf(&b, "  %s[\"%s: %s\"]\n", id, t.ID.Name(), mermaidEscape(t.Title))
+		if _, ok := byStatus[t.Status]; !ok {
+			statuses = append(statuses, t.Status)
+		}
+		byStatus[t.Status] = append(byStatus[t.Status], id)
+	}
+	for _, e := range edges {
+		arrow := "-->"
+		if e.subtask {
+			arrow = "-.-"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", mermaidID(e.from), arrow, mermaidID(e.to))
+	}
+	for _, status := range statuses {
+		class := strings.ReplaceAll(string(status), "-", "_")
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", class, statusColor(status))
+		fmt.Fprintf(&b, "  class %s %s\n", strings.Join(byStatus[status], ","), class)
+	}
+	for _, n := range nodes {
+		var style []string
+		switch n.task.Priority {
+		case PriorityCritical:
+			style = append(style, "stroke:#c0392b", "stroke-width:3px")
+		case PriorityHigh:
+			style = append(style, "stroke:#e67e22", "stroke-width:2px")
+		case PriorityLow:
+			style = append(style, "stroke:#7f8c8d")
+		}
+		if n.external {
+			style = append(style, "stroke-dasharray:5 5")
+		}
+		if len(style) > 0 {
+			fmt.Fprintf(&b, "  style %s %s\n", mermaidID(n.task.ID), strings.Join(style, ","))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func statusColor(s Status) string {
+	if c, ok := statusColors[s]; ok {
+		return c
+	}
+	return "#ffffff"
+}
+
+// dotQuote quotes a string for DOT, where newlines are written as \n.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// mermaidID returns a node identifier, Mermaid does not allow dots in them.
+func mermaidID(id TaskID) string {
+	return strings.ReplaceAll(id.Name(), ".", "_")
+}
+
+// mermaidEscape escapes the characters that end a quoted Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
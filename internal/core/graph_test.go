@@ -0,0 +1,57 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWriteGraph(t *testing.T) {
+	is := is.New(t)
+	tasks := []Task{
+		{ID: mustParseTaskID("01"), Title: "Design", Status: StatusDone, Priority: PriorityHigh},
+		{ID: mustParseTaskID("02"), Title: `Build "v2"`, Status: StatusInProgress, Priority: PriorityCritical, Dependencies: MaybeStringArray{"T01"}},
+		{ID: mustParseTaskID("02.01"), Parent: mustParseTaskID("02"), Title: "API", Status: StatusTodo, Priority: PriorityLow},
+		{ID: mustParseTaskID("03"), Title: "Release", Status: StatusTodo, Priority: PriorityMedium, Dependencies: MaybeStringArray{"T02", "T09"}},
+	}
+
+	var b bytes.Buffer
+	is.NoErr(WriteGraph(&b, tasks, GraphParams{}))
+	mermaid := b.String()
+	is.True(strings.HasPrefix(mermaid, "flowchart LR\n"))
+	for _, line := range []string{
+		`T02["T02: Build #quot;v2#quot;"]`,
+		"T02_01[\"T02.01: API\"]",
+		"T01 --> T02",
+		"T02 -.- T02_01",
+		"T02 --> T03",
+		"classDef in_progress fill:#fff2a8",
+		"class T02_01,T03 todo",
+		"style T02 stroke:#c0392b,stroke-width:3px",
+	} {
+		is.True(strings.Contains(mermaid, "  "+line+"\n")) // missing line
+	}
+	is.True(!strings.Contains(mermaid, "T09")) // dangling dependency
+
+	b.Reset()
+	is.NoErr(WriteGraph(&b, tasks, GraphParams{Root: "T02", Format: GraphFormatDOT}))
+	dot := b.String()
+	is.True(strings.HasPrefix(dot, "digraph backlog {\n"))
+	for _, line := range []string{
+		`"T02" [label="T02\nBuild \"v2\"", fillcolor="#fff2a8", tooltip="in-progress, critical priority", color="#c0392b", penwidth=3];`,
+		`"T01" [label="T01\nDesign", fillcolor="#b7e4c7", tooltip="done, high priority", color="#e67e22", penwidth=2, style="rounded,filled,dashed"];`,
+		`"T01" -> "T02";`,
+		`"T02" -> "T02.01" [style=dashed, arrowhead=none];`,
+	} {
+		is.True(strings.Contains(dot, "  "+line+"\n")) // missing line
+	}
+	is.True(!strings.Contains(dot, "T03")) // outside of the subtree
+
+	err := WriteGraph(&b, tasks, GraphParams{Format: "svg"})
+	is.True(errors.Is(err, ErrInvalid))
+	err = WriteGraph(&b, tasks, GraphParams{Root: "T42"})
+	is.True(errors.Is(err, ErrNotFound))
+}
@@ -30,6 +30,11 @@ type TaskID struct {
 	seg []int `json:"-"`
 }
 
+// ParseTaskID parses a task ID string (e.g., "T1.2.3" or "1.2.3").
+func ParseTaskID(id string) (TaskID, error) {
+	return parseTaskID(id)
+}
+
 // parseTaskID parses a task ID string (e.g., "T1.2.3" or "1.2.3") into a TaskID struct.
 func parseTaskID(id string) (TaskID, error) {
 	if id == "" {
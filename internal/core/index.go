@@ -0,0 +1,295 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+const (
+	// indexFileName is the name of the on-disk cache of parsed task files,
+	// stored at the root of the tasks directory.
+	indexFileName = ".index"
+	// indexVersion must be bumped whenever the cached representation changes,
+	// so that caches written by an older binary are discarded instead of misread.
+	indexVersion = 7
+	// racyWindow is the period after a modification during which a file's
+	// mtime cannot be trusted to change on the next write (coarse filesystem
+	// timestamps). Entries parsed within this window are re-read next time.
+	racyWindow = 2 * time.Second
+)
+
+// taskIndex caches the parsed content of every task file, keyed by the path
+// relative to the tasks directory. An entry is reused as long as the file's
+// modification time and size are unchanged.
+type taskIndex struct {
+	Version int                    `json:"version"`
+	Entries map[string]*indexEntry `json:"entries"`
+
+	dirty bool
+	// byID maps the ID and directory of the entries to their relative path,
+	// the first one in lexical order for duplicated IDs. It is rebuilt by
+	// refresh, or on the first lookup of a loaded index.
+	byID map[idKey]string
+	// workflow corrects the statuses of the tasks handed out, see indexEntry.task.
+	workflow Workflow
+}
+
+// idKey is the ID of a task file and its directory relative to the tasks directory.
+type idKey struct {
+	id, dir string
+}
+
+// indexEntry is a single cached task file.
+type indexEntry struct {
+	ID      string    `json:"id"` // ID derived from the file name
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Racy    bool      `json:"racy,omitempty"`
+	Err     string    `json:"error,omitempty"` // parse error, if the file is invalid
+	Task    Task      `json:"task"`
+	// KeyOrder is the order of the keys of the front matter, not part of the JSON of the task.
+	KeyOrder []string `json:"key_order,omitempty"`
+	// DateFields are the custom fields holding timestamps, see Task.dateFields.
+	DateFields []string `json:"date_fields,omitempty"`
+}
+
+// indexedTask is a task together with the path of the file it was read from.
+type indexedTask struct {
+	Path     string
+	Task     Task
+	Archived bool // the file is stored in the archived directory
+}
+
+func newTaskIndex() *taskIndex {
+	return &taskIndex{Version: indexVersion, Entries: make(map[string]*indexEntry)}
+}
+
+// loadTaskIndex reads the index from the tasks directory.
+// A missing, corrupted or outdated index yields an empty one, which is then
+// rebuilt from the task files.
+func loadTaskIndex(fs afero.Fs, tasksDir string) *taskIndex {
+	b, err := afero.ReadFile(fs, filepath.Join(tasksDir, indexFileName))
+	if err != nil {
+		return newTaskIndex()
+	}
+	idx := newTaskIndex()
+	if err := json.Unmarshal(b, idx); err != nil || idx.Version != indexVersion || idx.Entries == nil {
+		logging.Debug("discarding task index", "dir", tasksDir, "error", err)
+		return newTaskIndex()
+	}
+	return idx
+}
+
+// save writes the index back to the tasks directory if it changed.
+func (idx *taskIndex) save(fs afero.Fs, tasksDir string) error {
+	if !idx.dirty {
+		return nil
+	}
+	exists, err := afero.DirExists(fs, tasksDir)
+	if err != nil || !exists {
+		return err
+	}
+	b, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("encode task index: %w", err)
+	}
+	if err := WriteFileAtomic(fs, filepath.Join(tasksDir, indexFileName), b, 0o644); err != nil {
+		return fmt.Errorf("write task index: %w", err)
+	}
+	idx.dirty = false
+	return nil
+}
+
+// refresh walks the tasks directory and re-parses the files that were added
+// or modified since they were last indexed. Entries of deleted files are dropped.
+func (idx *taskIndex) refresh(fs afero.Fs, tasksDir string) error {
+	exists, err := afero.DirExists(fs, tasksDir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if len(idx.Entries) > 0 {
+			idx.Entries = make(map[string]*indexEntry)
+			idx.dirty = true
+		}
+		idx.byID = nil
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(idx.Entries))
+	walkErr := afero.Walk(fs, tasksDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isTaskFileName(info.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(tasksDir, path)
+		if err != nil {
+			return err
+		}
+		seen[rel] = struct{}{}
+		if e, ok := idx.Entries[rel]; ok && e.matches(info) {
+			return nil
+		}
+		return idx.parse(fs, rel, path, info)
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+
+	for rel := range idx.Entries {
+		if _, ok := seen[rel]; !ok {
+			delete(idx.Entries, rel)
+			idx.dirty = true
+		}
+	}
+	idx.reindex()
+	return nil
+}
+
+// reindex rebuilds the lookup of the entries by ID.
+func (idx *taskIndex) reindex() {
+	idx.byID = make(map[idKey]string, len(idx.Entries))
+	for rel, e := range idx.Entries {
+		key := idKey{id: e.ID, dir: filepath.Dir(rel)}
+		if other, ok := idx.byID[key]; !ok || rel < other {
+			idx.byID[key] = rel
+		}
+	}
+}
+
+// parse reads the file at path and stores it under rel.
+func (idx *taskIndex) parse(fs afero.Fs, rel, path string, info os.FileInfo) error {
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return err
+	}
+	e := &indexEntry{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Racy:    time.Since(info.ModTime()) < racyWindow,
+	}
+	if id, err := parseTaskIDfromFileName(info.Name()); err == nil {
+		e.ID = id.String()
+	}
+	task, err := parseTask(b)
+	if err != nil {
+		e.Err = err.Error()
+	} else {
+		e.Task = task
+		e.KeyOrder = task.keyOrder
+		e.DateFields = task.dateFields
+	}
+	idx.Entries[rel] = e
+	idx.dirty = true
+	return nil
+}
+
+// matches reports whether the cached entry still describes the file.
+func (e *indexEntry) matches(info os.FileInfo) bool {
+	return !e.Racy && e.Size == info.Size() && e.ModTime.Equal(info.ModTime())
+}
+
+// fresh reports whether the entry at rel can be used without walking the directory.
+func (idx *taskIndex) fresh(fs afero.Fs, tasksDir, rel string) bool {
+	e, ok := idx.Entries[rel]
+	if !ok {
+		return false
+	}
+	info, err := fs.Stat(filepath.Join(tasksDir, rel))
+	if err != nil {
+		return false
+	}
+	return e.matches(info)
+}
+
+// find returns the relative path of the task file with the given ID stored in
+// dir, a directory relative to the tasks directory ("." for the top level).
+func (idx *taskIndex) find(id TaskID, dir string) (string, bool) {
+	if idx.byID == nil {
+		idx.reindex()
+	}
+	rel, ok := idx.byID[idKey{id: id.String(), dir: dir}]
+	return rel, ok
+}
+
+// paths returns the relative paths of all entries in lexical order.
+func (idx *taskIndex) paths() []string {
+	return slices.Sorted(maps.Keys(idx.Entries))
+}
+
+// tasks returns the successfully parsed tasks stored in the given directories,
+// relative to the tasks directory ("." for the top level), in lexical order of
+// their paths.
+func (idx *taskIndex) tasks(tasksDir string, dirs ...string) []indexedTask {
+	var tasks []indexedTask
+	for _, rel := range idx.paths() {
+		e := idx.Entries[rel]
+		dir := filepath.Dir(rel)
+		if !slices.Contains(dirs, dir) || e.Err != "" {
+			continue
+		}
+		tasks = append(tasks, indexedTask{
+			Path:     filepath.Join(tasksDir, rel),
+			Task:     e.task(idx.workflow),
+			Archived: dir == archivedDir,
+		})
+	}
+	return tasks
+}
+
+func isTaskFileName(name string) bool {
+	return strings.HasPrefix(name, TaskIDPrefix) && strings.HasSuffix(name, ".md")
+}
+
+// task returns a copy of the cached task, its status corrected against the
+// workflow. The cache keeps the status as written since the workflow can change.
+func (e *indexEntry) task(w Workflow) Task {
+	t := e.Task.clone()
+	t.Status = w.correct(t.Status)
+	t.keyOrder = slices.Clone(e.KeyOrder)
+	t.dateFields = slices.Clone(e.DateFields)
+	return t
+}
+
+// clone returns a deep copy of the task so that cached values are never
+// mutated through the slices handed out to callers.
+func (t Task) clone() Task {
+	c := t
+	c.ID = TaskID{seg: slices.Clone(t.ID.seg)}
+	c.Parent = TaskID{seg: slices.Clone(t.Parent.seg)}
+	c.Assigned = slices.Clone(t.Assigned)
+	c.Labels = slices.Clone(t.Labels)
+	c.Dependencies = slices.Clone(t.Dependencies)
+	c.AcceptanceCriteria = slices.Clone(t.AcceptanceCriteria)
+	c.Fields = maps.Clone(t.Fields)
+	c.ExtraSections = slices.Clone(t.ExtraSections)
+	c.keyOrder = slices.Clone(t.keyOrder)
+	c.dateFields = slices.Clone(t.dateFields)
+	if t.Progress != nil {
+		p := *t.Progress
+		c.Progress = &p
+	}
+	if t.Effort != nil {
+		e := *t.Effort
+		c.Effort = &e
+	}
+	if t.History != nil {
+		c.History = make([]HistoryEntry, len(t.History))
+		for i, h := range t.History {
+			h.Metadata = maps.Clone(h.Metadata)
+			c.History[i] = h
+		}
+	}
+	return c
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+// ageFiles moves the modification time of every task file out of the racy
+// window so that index entries are trusted on the next refresh.
+func ageFiles(t *testing.T, fs afero.Fs, tasksDir string) {
+	t.Helper()
+	old := time.Now().Add(-time.Hour)
+	entries, err := afero.ReadDir(fs, tasksDir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	for _, e := range entries {
+		if isTaskFileName(e.Name()) {
+			if err := fs.Chtimes(filepath.Join(tasksDir, e.Name()), old, old); err != nil {
+				t.Fatalf("chtimes: %v", err)
+			}
+		}
+	}
+}
+
+func TestTaskIndex_PersistedByList(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Task Two", Parent: "T01"})
+	is.NoErr(err)
+
+	_, err = store.List(ListTasksParams{})
+	is.NoErr(err)
+
+	b, err := afero.ReadFile(fs, filepath.Join(".backlog", indexFileName))
+	is.NoErr(err)
+	var idx taskIndex
+	is.NoErr(json.Unmarshal(b, &idx))
+	is.Equal(idx.Version, indexVersion)
+	is.Equal(len(idx.Entries), 2)
+	is.Equal(idx.Entries["T01.01-task_two.md"].ID, "01.01")
+	is.Equal(idx.Entries["T01.01-task_two.md"].Task.Title, "Task Two")
+}
+
+func TestTaskIndex_ReusesUnchangedFiles(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	created, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+	ageFiles(t, fs, ".backlog")
+
+	// Build the index, then tamper with the cached title to observe cache hits.
+	_, err = store.List(ListTasksParams{})
+	is.NoErr(err)
+	idx := loadTaskIndex(fs, ".backlog")
+	is.True(!idx.Entries[created.FileName()].Racy)
+	idx.Entries[created.FileName()].Task.Title = "Cached Title"
+	idx.dirty = true
+	is.NoErr(idx.save(fs, ".backlog"))
+
+	fresh := NewFileTaskStore(fs, ".backlog")
+	task, err := fresh.Get("T01")
+	is.NoErr(err)
+	is.Equal(task.Title, "Cached Title") // served from the index
+	listResult, err := fresh.List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(listResult.Tasks[0].Title, "Cached Title")
+
+	// Touching the file invalidates the entry.
+	now := time.Now()
+	is.NoErr(fs.Chtimes(store.Path(created), now, now))
+	task, err = fresh.Get("T01")
+	is.NoErr(err)
+	is.Equal(task.Title, "Task One")
+}
+
+func TestTaskIndex_DropsDeletedFiles(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+	two, err := store.Create(CreateTaskParams{Title: "Task Two"})
+	is.NoErr(err)
+
+	_, err = store.List(ListTasksParams{})
+	is.NoErr(err)
+	is.NoErr(fs.Remove(store.Path(two)))
+
+	listResult, err := store.List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(len(listResult.Tasks), 1)
+	_, err = store.Get("T02")
+	is.True(err != nil)
+
+	next, err := store.getNextTaskID()
+	is.NoErr(err)
+	is.Equal(next.String(), "02")
+}
+
+func TestTaskIndex_CorruptedIndexIsRebuilt(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+	is.NoErr(afero.WriteFile(fs, filepath.Join(".backlog", indexFileName), []byte("{not json"), 0o644))
+
+	fresh := NewFileTaskStore(fs, ".backlog")
+	task, err := fresh.Get("1")
+	is.NoErr(err)
+	is.Equal(task.Title, "Task One")
+}
+
+func TestTaskIndex_CachedTasksAreNotShared(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One", Labels: []string{"a"}, AC: []string{"first"}})
+	is.NoErr(err)
+
+	task, err := store.Get("1")
+	is.NoErr(err)
+	task.Labels[0] = "mutated"
+	task.AcceptanceCriteria[0].Checked = true
+
+	again, err := store.Get("1")
+	is.NoErr(err)
+	is.Equal(again.Labels[0], "a")
+	is.True(!again.AcceptanceCriteria[0].Checked)
+}
+
+func TestTaskIndex_NoGitignore(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+	_, err = store.List(ListTasksParams{})
+	is.NoErr(err)
+
+	// only the tasks and the index, which users ignore themselves, are written
+	_, err = fs.Stat(filepath.Join(".backlog", ".gitignore"))
+	is.True(errors.Is(err, os.ErrNotExist))
+	_, err = fs.Stat(filepath.Join(".backlog", indexFileName))
+	is.NoErr(err)
+}
@@ -2,12 +2,11 @@ package core
 
 import (
 	"fmt"
-	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"strings"
-
-	"github.com/spf13/afero"
+	"time"
 )
 
 // ListTasksParams holds the parameters for listing tasks.
@@ -16,73 +15,104 @@ type ListTasksParams struct {
 	Status        []string `json:"status,omitempty"         jsonschema:"Filter tasks by status."`
 	Assigned      []string `json:"assigned,omitempty"       jsonschema:"Filter tasks by assignee."`
 	Labels        []string `json:"labels,omitempty"         jsonschema:"Filter tasks by label."`
-	Sort          []string `json:"sort,omitempty"           jsonschema:"Fields to sort by."`
+	Sort          []string `json:"sort,omitempty"           jsonschema:"Fields to sort by: id, title, status, priority, start, due, created, updated or a custom field."`
 	Priority      string   `json:"priority,omitempty"       jsonschema:"Filter tasks by priority."`
 	Query         string   `json:"query,omitempty"          jsonschema:"Search query to filter tasks by."`
 	Unassigned    bool     `json:"unassigned,omitempty"     jsonschema:"Filter tasks that have no one assigned."`
 	DependedOn    bool     `json:"depended_on,omitempty"    jsonschema:"Filter tasks that other tasks depend on."`
 	HasDependency bool     `json:"has_dependency,omitempty" jsonschema:"Filter tasks that have at least one dependency."`
 	Reverse       bool     `json:"reverse,omitempty"        jsonschema:"Reverse the sort order."`
+	// Time bounds: RFC 3339 times, dates or ages relative to now such as 7d, see parseTimeBound.
+	CreatedAfter  string `json:"created_after,omitempty"  jsonschema:"Only tasks created at or after this time: RFC 3339, a date (2025-09-01) or an age like 7d or 2w."`
+	CreatedBefore string `json:"created_before,omitempty" jsonschema:"Only tasks created before this time: RFC 3339, a date (2025-09-01) or an age like 7d or 2w."`
+	UpdatedSince  string `json:"updated_since,omitempty"  jsonschema:"Only tasks updated, or created if never updated, at or after this time: RFC 3339, a date (2025-09-01) or an age like 7d or 2w."`
+	// Due dates, see ParseDate.
+	Overdue   bool   `json:"overdue,omitempty"    jsonschema:"Only tasks due before today that are not done, cancelled, archived or in a final status of the workflow."`
+	DueBefore string `json:"due_before,omitempty" jsonschema:"Only tasks due before this day: YYYY-MM-DD, today, tomorrow, a weekday like friday or a number of days like +7d."`
+	// Where is a query combining conditions on the fields of the tasks, see parseWhere.
+	Where string `json:"where,omitempty" jsonschema:"Query combining conditions with and, or, not and parentheses, e.g. 'status:todo and (label:bug or priority>=high) and updated<7d'. Fields: id, parent, dep, status, priority, label, assigned, title, description, plan, notes, ac, text, start, due, created, updated and the custom fields. Operators: ':' (contains for text, equals otherwise), '=', '!=', '<', '<=', '>', '>='. Dates are YYYY-MM-DD or ages like 7d, 2w, 12h, start and due also take today, friday or +3d."`
+	// View names a saved view whose parameters are used for the ones left empty, see View.
+	View string `json:"view,omitempty" jsonschema:"Name of a saved view whose filters, sorting and limit apply to the parameters left empty."`
+	// Archived selects whether archived tasks are excluded (default), included or the only ones listed.
+	Archived ArchivedMode `json:"archived,omitempty" jsonschema:"Archived tasks: 'exclude' (default), 'include' or 'only'."`
 	// Pagination
 	Limit  int `json:"limit,omitempty"  jsonschema:"Maximum number of tasks to return (0 means no limit)."`
 	Offset int `json:"offset,omitempty" jsonschema:"Number of tasks to skip from the beginning."`
 }
 
+// ArchivedMode selects how archived tasks are treated when listing tasks.
+type ArchivedMode string
+
+const (
+	ArchivedExclude ArchivedMode = "exclude" // only active tasks, the default
+	ArchivedInclude ArchivedMode = "include" // active and archived tasks
+	ArchivedOnly    ArchivedMode = "only"    // only archived tasks
+)
+
+// dirs returns the directories, relative to the tasks directory, that hold
+// the tasks selected by the mode.
+func (m ArchivedMode) dirs() ([]string, error) {
+	switch m {
+	case "", ArchivedExclude:
+		return []string{"."}, nil
+	case ArchivedInclude:
+		return []string{".", archivedDir}, nil
+	case ArchivedOnly:
+		return []string{archivedDir}, nil
+	default:
+		return nil, fmt.Errorf("archived mode %q (want %q, %q or %q): %w", string(m), ArchivedExclude, ArchivedInclude, ArchivedOnly, ErrInvalid)
+	}
+}
+
 // List implements TaskStore.
 func (f *FileTaskStore) List(params ListTasksParams) (result ListResult, err error) {
+	if err := f.applyView(&params); err != nil {
+		return result, err
+	}
+	dirs, err := params.Archived.dirs()
+	if err != nil {
+		return result, err
+	}
 	// Load all tasks from filesystem
-	tasks, err := f.loadAll()
+	tasks, err := f.loadAll(dirs...)
 	if err != nil {
 		return result, fmt.Errorf("loading tasks: %v", err)
 	}
-	filteredTasks, err := filterTasks(tasks, params)
+	setProgress(tasks, tasks, f.workflow)
+	filteredTasks, err := filterTasks(tasks, params, f.workflow, f.fields)
 	if err != nil {
-		return result, fmt.Errorf("filtering tasks: %v", err)
+		return result, fmt.Errorf("filtering tasks: %w", err)
 	}
-	sortTasks(filteredTasks, params.Sort, params.Reverse)
+	sortTasks(filteredTasks, params.Sort, params.Reverse, f.fields)
 	listResult := Paginate(filteredTasks, params.Limit, params.Offset)
 	return listResult, nil
 }
 
-// LoadAll loads all tasks from the tasks directory.
-func (f *FileTaskStore) loadAll() ([]Task, error) {
-	exists, err := afero.DirExists(f.fs, f.tasksDir)
+// loadAll loads all tasks stored in the given directories, relative to the
+// tasks directory, using the index to avoid parsing files that did not change.
+func (f *FileTaskStore) loadAll(dirs ...string) ([]Task, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	idx, err := f.index()
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return []Task{}, nil
-	}
-
-	var tasks []Task
-	walkErr := afero.Walk(f.fs, f.tasksDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	tasks := make([]Task, 0, len(idx.Entries))
+	for _, rel := range idx.paths() {
+		if !slices.Contains(dirs, filepath.Dir(rel)) {
+			continue
 		}
-		if !info.IsDir() && strings.HasPrefix(info.Name(), TaskIDPrefix) && strings.HasSuffix(info.Name(), ".md") {
-			b, err := afero.ReadFile(f.fs, path)
-			if err != nil {
-				return err
-			}
-
-			task, err := parseTask(b)
-			if err != nil {
-				return fmt.Errorf("parse task %s: %v", path, err)
-			}
-			tasks = append(tasks, task)
+		e := idx.Entries[rel]
+		if e.Err != "" {
+			return nil, fmt.Errorf("parse task %s: %s", filepath.Join(f.tasksDir, rel), e.Err)
 		}
-		return nil
-	})
-
-	if walkErr != nil {
-		return nil, walkErr
+		tasks = append(tasks, e.task(idx.workflow))
 	}
-
 	return tasks, nil
 }
 
 // filterTasks applies filtering logic to a slice of tasks
-func filterTasks(tasks []Task, params ListTasksParams) ([]Task, error) {
+func filterTasks(tasks []Task, params ListTasksParams, workflow Workflow, fields []FieldDef) ([]Task, error) {
 	var parentID TaskID
 	var statuses []Status
 	var assigned []string
@@ -92,6 +122,37 @@ func filterTasks(tasks []Task, params ListTasksParams) ([]Task, error) {
 	var isPrioritySet bool
 	var err error
 
+	now := time.Now()
+	var where taskPredicate
+	if params.Where != "" {
+		where, err = parseWhere(params.Where, whereEnv{now: now, workflow: workflow, fields: fields})
+		if err != nil {
+			return nil, err
+		}
+	}
+	var createdAfter, createdBefore, updatedSince time.Time
+	for _, bound := range []struct {
+		name  string
+		value string
+		t     *time.Time
+	}{
+		{"created after", params.CreatedAfter, &createdAfter},
+		{"created before", params.CreatedBefore, &createdBefore},
+		{"updated since", params.UpdatedSince, &updatedSince},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		if *bound.t, err = parseTimeBound(bound.value, now); err != nil {
+			return nil, fmt.Errorf("%s: %w", bound.name, err)
+		}
+	}
+	var dueBefore Date
+	if dueBefore, err = ParseDate(params.DueBefore, now); err != nil {
+		return nil, fmt.Errorf("due before: %w", err)
+	}
+	today := DateOf(now)
+
 	if params.Parent != "" {
 		parentID, err = parseTaskID(params.Parent)
 		if err != nil {
@@ -107,7 +168,7 @@ func filterTasks(tasks []Task, params ListTasksParams) ([]Task, error) {
 		isPrioritySet = true
 	}
 	for _, s := range params.Status {
-		status, err := ParseStatus(s)
+		status, err := workflow.Parse(s)
 		if err != nil {
 			return nil, fmt.Errorf("status '%s': %w", s, err)
 		}
@@ -132,13 +193,28 @@ func filterTasks(tasks []Task, params ListTasksParams) ([]Task, error) {
 		filteredTasks = dependentGraph(filteredTasks)
 	}
 
+	if where != nil {
+		matches := make([]Task, 0, len(filteredTasks))
+		for _, t := range filteredTasks {
+			if where(t) {
+				matches = append(matches, t)
+			}
+		}
+		filteredTasks = matches
+	}
+
 	if !isParentSet &&
 		!isPrioritySet &&
 		len(statuses) == 0 &&
 		len(assigned) == 0 &&
 		len(labels) == 0 &&
 		!params.Unassigned &&
-		!params.HasDependency {
+		!params.HasDependency &&
+		createdAfter.IsZero() &&
+		createdBefore.IsZero() &&
+		updatedSince.IsZero() &&
+		!params.Overdue &&
+		dueBefore == "" {
 		return filteredTasks, nil
 	}
 
@@ -165,6 +241,21 @@ func filterTasks(tasks []Task, params ListTasksParams) ([]Task, error) {
 		if params.HasDependency && len(t.Dependencies) == 0 {
 			continue
 		}
+		if !createdAfter.IsZero() && t.CreatedAt.Before(createdAfter) {
+			continue
+		}
+		if !createdBefore.IsZero() && !t.CreatedAt.Before(createdBefore) {
+			continue
+		}
+		if !updatedSince.IsZero() && t.Version().Before(updatedSince) {
+			continue
+		}
+		if params.Overdue && !t.Overdue(today, workflow) {
+			continue
+		}
+		if dueBefore != "" && (t.Due == "" || t.Due >= dueBefore) {
+			continue
+		}
 		finalFilteredTasks = append(finalFilteredTasks, t)
 	}
 
@@ -174,40 +265,9 @@ func filterTasks(tasks []Task, params ListTasksParams) ([]Task, error) {
 func searchTasks(tasks []Task, query string) []Task {
 	matches := []Task{}
 	queryLower := strings.ToLower(query)
-
 	for _, task := range tasks {
-		// Search in task title, description, and other text fields
-		if strings.Contains(strings.ToLower(task.Title), queryLower) ||
-			strings.Contains(strings.ToLower(task.Description), queryLower) ||
-			strings.Contains(strings.ToLower(task.ImplementationPlan), queryLower) ||
-			strings.Contains(strings.ToLower(task.ImplementationNotes), queryLower) ||
-			strings.Contains(strings.ToLower(task.Priority.String()), queryLower) {
+		if taskContains(task, queryLower) {
 			matches = append(matches, task)
-			continue
-		}
-
-		// Search in acceptance criteria
-		for _, ac := range task.AcceptanceCriteria {
-			if strings.Contains(strings.ToLower(ac.Text), queryLower) {
-				matches = append(matches, task)
-				break
-			}
-		}
-
-		// Search in labels and assigned names
-		for _, label := range task.Labels {
-			if strings.Contains(strings.ToLower(label), queryLower) {
-				matches = append(matches, task)
-				break
-			}
-		}
-
-		// Search in names assigned
-		for _, assignee := range task.Assigned {
-			if strings.Contains(strings.ToLower(assignee), queryLower) {
-				matches = append(matches, task)
-				break
-			}
 		}
 	}
 	return matches
@@ -258,8 +318,9 @@ func atLeastOneIntersect[S ~[]E, E comparable](got, want S) bool {
 }
 
 // sortTasks sorts the tasks slice based on the provided sort fields.
-// Supported sort fields: id, title, status, priority, created, updated
-func sortTasks(tasks []Task, sortFields []string, reverse bool) {
+// Supported sort fields: id, title, status, priority, start, due, created,
+// updated and the custom fields. Tasks without a date come after the others.
+func sortTasks(tasks []Task, sortFields []string, reverse bool, fields []FieldDef) {
 	if len(sortFields) == 0 {
 		// No sorting requested, but still apply reverse if requested
 		if reverse {
@@ -294,6 +355,10 @@ func sortTasks(tasks []Task, sortFields []string, reverse bool) {
 					return false
 				}
 				cmp = 0
+			case "start":
+				cmp = compareDates(t1.Start, t2.Start)
+			case "due":
+				cmp = compareDates(t1.Due, t2.Due)
 			case "created":
 				if t1.CreatedAt.Before(t2.CreatedAt) {
 					return true
@@ -309,8 +374,12 @@ func sortTasks(tasks []Task, sortFields []string, reverse bool) {
 				}
 				cmp = 0
 			default:
-				// Unknown field, skip
-				continue
+				def, ok := lookupField(fields, field)
+				if !ok {
+					// Unknown field, skip
+					continue
+				}
+				cmp = compareFieldValues(def, t1.Fields[def.Name], t2.Fields[def.Name])
 			}
 
 			if cmp < 0 {
@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"errors"
 	"sort"
 	"testing"
 
@@ -47,6 +48,45 @@ func TestListTasks(t *testing.T) {
 	}
 }
 
+func TestListTasks_Archived(t *testing.T) {
+	is := is.New(t)
+	store := core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	_, err := store.Create(core.CreateTaskParams{Title: "Active login task"})
+	is.NoErr(err)
+	archived, err := store.Create(core.CreateTaskParams{Title: "Archived login task"})
+	is.NoErr(err)
+	_, err = store.Archive(archived.ID)
+	is.NoErr(err)
+
+	tests := []struct {
+		name   string
+		params core.ListTasksParams
+		titles []string
+	}{
+		{"excluded by default", core.ListTasksParams{}, []string{"Active login task"}},
+		{"exclude", core.ListTasksParams{Archived: core.ArchivedExclude}, []string{"Active login task"}},
+		{"include", core.ListTasksParams{Archived: core.ArchivedInclude}, []string{"Active login task", "Archived login task"}},
+		{"only", core.ListTasksParams{Archived: core.ArchivedOnly}, []string{"Archived login task"}},
+		{"query excludes archived", core.ListTasksParams{Query: "login"}, []string{"Active login task"}},
+		{"query only archived", core.ListTasksParams{Query: "login", Archived: core.ArchivedOnly}, []string{"Archived login task"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			listResult, err := store.List(tt.params)
+			is.NoErr(err)
+			var titles []string
+			for _, task := range listResult.Tasks {
+				titles = append(titles, task.Title)
+			}
+			is.Equal(titles, tt.titles)
+		})
+	}
+
+	_, err = store.List(core.ListTasksParams{Archived: "sometimes"})
+	is.True(errors.Is(err, core.ErrInvalid))
+}
+
 func TestFilterAndSortTasks(t *testing.T) {
 	store := core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
 
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+// lockFileName is the advisory lock taken on the tasks directory by the
+// operations modifying it, so that concurrent processes (CLI invocations,
+// MCP servers) do not interleave their read-modify-write sequences.
+const lockFileName = ".lock"
+
+var (
+	// lockTimeout is how long to wait for another process to release the lock.
+	lockTimeout = 10 * time.Second
+	// lockStale is the age after which a lock is considered left over by a
+	// crashed process and is broken.
+	lockStale = time.Minute
+	lockRetry = 20 * time.Millisecond
+)
+
+// ErrLocked is returned when the tasks directory stays locked by another process.
+var ErrLocked = errors.New("tasks directory is locked")
+
+// lockSeq tells apart the locks taken by the stores of a process.
+var lockSeq atomic.Int64
+
+// lock acquires the advisory lock on the tasks directory and returns the
+// function releasing it. Within the process, operations are serialized by
+// f.writeMu, which is held until the lock is released.
+func (f *FileTaskStore) lock() (unlock func(), err error) {
+	f.writeMu.Lock()
+	defer func() {
+		if err != nil {
+			f.writeMu.Unlock()
+		}
+	}()
+	if err := f.fs.MkdirAll(f.tasksDir, 0o750); err != nil {
+		return nil, fmt.Errorf("could not create tasks directory %q: %w", f.tasksDir, err)
+	}
+
+	path := filepath.Join(f.tasksDir, lockFileName)
+	owner := fmt.Sprintf("pid %d #%d", os.Getpid(), lockSeq.Add(1))
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := f.fs.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, err = file.WriteString(owner)
+			if cerr := file.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = f.fs.Remove(path)
+				return nil, fmt.Errorf("write lock file: %w", err)
+			}
+			return func() {
+				if holder, err := afero.ReadFile(f.fs, path); err != nil || string(holder) != owner {
+					logging.Warn("lock was broken while held", "path", path, "holder", holder)
+				} else if err := f.fs.Remove(path); err != nil {
+					logging.Warn("could not release lock", "path", path, "error", err)
+				}
+				f.writeMu.Unlock()
+			}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("create lock file: %w", err)
+		}
+
+		info, statErr := f.fs.Stat(path)
+		if statErr == nil && time.Since(info.ModTime()) > lockStale {
+			if holder, err := afero.ReadFile(f.fs, path); err == nil {
+				logging.Warn("breaking stale lock", "path", path, "holder", holder, "since", info.ModTime())
+				f.breakStaleLock(path, holder, info.ModTime())
+			}
+			continue
+		}
+		if time.Now().After(deadline) {
+			holder, _ := afero.ReadFile(f.fs, path)
+			return nil, fmt.Errorf("%w: %s held by %s", ErrLocked, path, holder)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// breakStaleLock removes the lock at path left over by a crashed process, last
+// seen with the given content and modification time. Checking the lock then
+// removing it would remove the fresh lock of a process that broke it first, so
+// the lock is renamed to a name of its own, which only one process can do,
+// then checked to be the stale one before removal. A fresh lock is put back.
+func (f *FileTaskStore) breakStaleLock(path string, holder []byte, modTime time.Time) {
+	aside := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), lockSeq.Add(1))
+	if err := f.fs.Rename(path, aside); err != nil {
+		return // released or broken by another process
+	}
+	b, err := afero.ReadFile(f.fs, aside)
+	info, statErr := f.fs.Stat(aside)
+	if err == nil && statErr == nil && bytes.Equal(b, holder) && info.ModTime().Equal(modTime) {
+		_ = f.fs.Remove(aside)
+		return
+	}
+	// Another process broke the stale lock and took the lock in the meantime.
+	if exists, _ := afero.Exists(f.fs, path); !exists {
+		if err := f.fs.Rename(aside, path); err == nil {
+			return
+		}
+	}
+	logging.Warn("could not put back the lock taken while breaking a stale lock", "path", path, "holder", b)
+	_ = f.fs.Remove(aside)
+}
+
+// WriteFileAtomic writes data to a temporary file in the directory of path and
+// renames it over path, so that readers never observe a partially written file.
+func WriteFileAtomic(fs afero.Fs, path string, data []byte, perm os.FileMode) error {
+	// The leading dot keeps the temporary file from being taken for a task file.
+	tmp, err := afero.TempFile(fs, filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = fs.Chmod(tmp.Name(), perm)
+	}
+	if err == nil {
+		err = fs.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = fs.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
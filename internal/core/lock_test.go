@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestLock_ConcurrentStores(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	// Two stores sharing the directory behave like two processes.
+	stores := []*FileTaskStore{NewFileTaskStore(fs, ".backlog"), NewFileTaskStore(fs, ".backlog")}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := range 20 {
+		wg.Go(func() {
+			_, err := stores[i%2].Create(CreateTaskParams{Title: fmt.Sprintf("Task %d", i)})
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		is.NoErr(err)
+	}
+
+	listResult, err := stores[0].List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(len(listResult.Tasks), 20)
+	seen := map[string]bool{}
+	for _, task := range listResult.Tasks {
+		is.True(!seen[task.ID.String()]) // IDs are unique
+		seen[task.ID.String()] = true
+	}
+	exists, err := afero.Exists(fs, filepath.Join(".backlog", lockFileName))
+	is.NoErr(err)
+	is.True(!exists) // the lock is released
+}
+
+func TestLock_Timeout(t *testing.T) {
+	is := is.New(t)
+	defer func(timeout time.Duration) { lockTimeout = timeout }(lockTimeout)
+	lockTimeout = 50 * time.Millisecond
+
+	fs := afero.NewMemMapFs()
+	is.NoErr(fs.MkdirAll(".backlog", 0o750))
+	is.NoErr(afero.WriteFile(fs, filepath.Join(".backlog", lockFileName), []byte("pid 1"), 0o644))
+
+	store := NewFileTaskStore(fs, ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Task One"})
+	is.True(errors.Is(err, ErrLocked))
+
+	// A lock left over by a crashed process is broken.
+	old := time.Now().Add(-2 * lockStale)
+	is.NoErr(fs.Chtimes(filepath.Join(".backlog", lockFileName), old, old))
+	_, err = store.Create(CreateTaskParams{Title: "Task One"})
+	is.NoErr(err)
+}
+
+func TestLock_BreakStale(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	is.NoErr(fs.MkdirAll(".backlog", 0o750))
+	path := filepath.Join(".backlog", lockFileName)
+	store := NewFileTaskStore(fs, ".backlog")
+	old := time.Now().Add(-2 * lockStale)
+
+	// Another process broke the stale lock and took the lock after it was seen.
+	is.NoErr(afero.WriteFile(fs, path, []byte("pid 2"), 0o644))
+	store.breakStaleLock(path, []byte("pid 1"), old)
+	b, err := afero.ReadFile(fs, path)
+	is.NoErr(err)
+	is.Equal(string(b), "pid 2") // the fresh lock is kept
+
+	is.NoErr(fs.Chtimes(path, old, old))
+	info, err := fs.Stat(path)
+	is.NoErr(err)
+	store.breakStaleLock(path, []byte("pid 2"), info.ModTime())
+	entries, err := afero.ReadDir(fs, ".backlog")
+	is.NoErr(err)
+	is.Equal(len(entries), 0) // the stale lock is removed
+
+	// A lock broken while held is not released by its former holder.
+	unlock, err := store.lock()
+	is.NoErr(err)
+	is.NoErr(afero.WriteFile(fs, path, []byte("pid 3"), 0o644))
+	unlock()
+	b, err = afero.ReadFile(fs, path)
+	is.NoErr(err)
+	is.Equal(string(b), "pid 3")
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	is.NoErr(fs.MkdirAll("dir", 0o750))
+	is.NoErr(afero.WriteFile(fs, filepath.Join("dir", "file.md"), []byte("old content"), 0o644))
+
+	is.NoErr(WriteFileAtomic(fs, filepath.Join("dir", "file.md"), []byte("new"), 0o644))
+
+	b, err := afero.ReadFile(fs, filepath.Join("dir", "file.md"))
+	is.NoErr(err)
+	is.Equal(string(b), "new")
+	entries, err := afero.ReadDir(fs, "dir")
+	is.NoErr(err)
+	is.Equal(len(entries), 1) // no temporary file left behind
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MoveTaskParams holds the parameters for moving a task.
+type MoveTaskParams struct {
+	ID string `json:"id" jsonschema:"Required. The ID of the task to move."`
+	To string `json:"to" jsonschema:"Required. The ID of the new parent task, or 'root' to make it a top-level task."`
+}
+
+// MovedTask is a task renumbered by a move.
+type MovedTask struct {
+	OldID   TaskID `json:"old_id"`
+	OldPath string `json:"old_path"`
+	Path    string `json:"path"`
+	Task    Task   `json:"task"`
+}
+
+// MoveResult lists the moved task followed by its subtasks.
+type MoveResult struct {
+	Moved []MovedTask `json:"moved"`
+	// References are the other tasks whose dependencies on the moved tasks were rewritten.
+	References []Task `json:"references,omitempty"`
+	// Parents are the parent tasks marked done because the moved task completed them.
+	Parents []Task `json:"parents,omitempty"`
+}
+
+// Paths returns the files changed by the move, given the path of a task file:
+// the new and old files of the moved tasks and the files of the tasks whose
+// dependencies were rewritten or that were marked done.
+func (r MoveResult) Paths(path func(Task) string) []string {
+	var paths []string
+	for _, moved := range r.Moved {
+		paths = append(paths, moved.Path, moved.OldPath)
+	}
+	for _, t := range slices.Concat(r.References, r.Parents) {
+		paths = append(paths, path(t))
+	}
+	return paths
+}
+
+// Move makes a task a subtask of another task, or a top-level task when the
+// destination is "root". The task and its whole subtree are renumbered, and
+// the dependencies on them are rewritten.
+func (f *FileTaskStore) Move(params MoveTaskParams) (MoveResult, error) {
+	var result MoveResult
+	id, err := parseTaskID(params.ID)
+	if err != nil {
+		return result, fmt.Errorf("invalid task ID '%s': %w", params.ID, err)
+	}
+	to := params.To
+	if strings.EqualFold(to, "root") {
+		to = ""
+	}
+	toID, err := parseTaskID(to)
+	if err != nil {
+		return result, fmt.Errorf("invalid destination '%s': %w", params.To, err)
+	}
+
+	unlock, err := f.lock()
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	found, err := f.findTaskFileIn(id, ".")
+	if err != nil {
+		return result, fmt.Errorf("get task %q: %w", params.ID, err)
+	}
+	task := found.Task
+	if task.Parent.Equals(toID) {
+		return result, fmt.Errorf("task %s is already under %s: %w", task.ID, cmp.Or(to, "root"), ErrInvalid)
+	}
+
+	edited, err := f.update(&task, EditTaskParams{NewParent: &to})
+	if err != nil {
+		return result, err
+	}
+	result.Moved = append([]MovedTask{{OldID: id, OldPath: found.Path, Path: f.Path(task), Task: task}}, edited.Moved...)
+	result.References = edited.References
+	result.Parents = edited.Parents
+	return result, nil
+}
+
+// moveSubtree renumbers the subtasks of the task whose ID changed from oldID
+// to newID, then rewrites all the references to the renumbered tasks. It
+// returns the moved subtasks and the other tasks rewritten, the task itself
+// included. The caller must hold the lock.
+func (f *FileTaskStore) moveSubtree(oldID, newID TaskID) (moved []MovedTask, rewritten []Task, err error) {
+	idChanges := map[string]TaskID{oldID.String(): newID}
+	for _, t := range f.descendants(oldID) {
+		task := t.Task
+		id := rebase(task.ID, oldID, newID)
+		parent := *id.Parent()
+		RecordParentChange(&task, task.Parent, parent, "parent moved")
+		RecordIDChange(&task, task.ID, id, "parent moved", nil)
+		idChanges[task.ID.String()] = id
+		task.ID = id
+		task.Parent = parent
+		task.UpdatedAt = time.Now().UTC()
+		if err := f.write(task); err != nil {
+			return nil, nil, fmt.Errorf("could not write task %s: %w", id, err)
+		}
+		if err := f.fs.Remove(t.Path); err != nil {
+			return nil, nil, fmt.Errorf("could not remove old file: %w", err)
+		}
+		moved = append(moved, MovedTask{OldID: t.Task.ID, OldPath: t.Path, Path: f.Path(task), Task: task})
+	}
+
+	updater := NewReferenceUpdater(f.newConflictDetector(), f)
+	updated, err := updater.UpdateReferences(idChanges)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, t := range updated {
+		// Moved tasks depending on each other are listed with the moved tasks.
+		i := slices.IndexFunc(moved, func(m MovedTask) bool { return m.Task.ID.Equals(t.ID) })
+		if i >= 0 {
+			moved[i].Task = t
+		} else {
+			rewritten = append(rewritten, t)
+		}
+	}
+	return moved, rewritten, nil
+}
+
+// descendants returns the active subtasks, at any depth, of the given task.
+func (f *FileTaskStore) descendants(id TaskID) []indexedTask {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	idx, err := f.index()
+	if err != nil {
+		return nil
+	}
+	return slices.DeleteFunc(idx.tasks(f.tasksDir, "."), func(t indexedTask) bool {
+		return !isDescendant(t.Task.ID, id)
+	})
+}
+
+// rebase replaces the prefix from of id by to, e.g. rebase(3.1.2, 3.1, 5) is 5.2.
+func rebase(id, from, to TaskID) TaskID {
+	return TaskID{seg: slices.Concat(to.seg, id.seg[len(from.seg):])}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestMoveTask(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Epic"},
+		{Title: "Other epic"},
+		{Title: "Story", Parent: "T02"},
+		{Title: "Subtask", Parent: "T02.01"},
+		{Title: "Dependent", Dependencies: []string{"T02.01", "T02.01.01"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+
+	result, err := store.Move(MoveTaskParams{ID: "T02.01", To: "T01"})
+	is.NoErr(err)
+	is.Equal(len(result.Moved), 2)
+	is.Equal(result.Moved[0].OldID.String(), "02.01")
+	is.Equal(result.Moved[0].Task.ID.String(), "01.01")
+	is.Equal(result.Moved[1].OldID.String(), "02.01.01")
+	is.Equal(result.Moved[1].Task.ID.String(), "01.01.01")
+	is.Equal(len(result.References), 1) // the dependencies of the dependent task were rewritten
+	is.Equal(result.References[0].Title, "Dependent")
+	is.Equal(len(result.Paths(store.Path)), 5)
+	for _, m := range result.Moved {
+		exists, err := afero.Exists(fs, m.OldPath)
+		is.NoErr(err)
+		is.True(!exists)
+		exists, err = afero.Exists(fs, m.Path)
+		is.NoErr(err)
+		is.True(exists)
+	}
+
+	story, err := store.Get("T01.01")
+	is.NoErr(err)
+	is.Equal(story.Title, "Story")
+	is.Equal(story.Parent.String(), "01")
+	subtask, err := store.Get("T01.01.01")
+	is.NoErr(err)
+	is.Equal(subtask.Title, "Subtask")
+	is.Equal(subtask.Parent.String(), "01.01")
+	original, ok := subtask.GetOriginalID()
+	is.True(ok)
+	is.Equal(original.String(), "02.01.01")
+
+	dependent, err := store.Get("T03")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T01.01", "T01.01.01"})
+
+	// Back to the top level
+	result, err = store.Move(MoveTaskParams{ID: "T01.01", To: "root"})
+	is.NoErr(err)
+	is.Equal(result.Moved[0].Task.ID.String(), "04")
+	is.True(result.Moved[0].Task.Parent.IsZero())
+	is.Equal(result.Moved[1].Task.ID.String(), "04.01")
+	dependent, err = store.Get("T03")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T04", "T04.01"})
+}
+
+func TestMoveTask_Invalid(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	_, err := store.Create(CreateTaskParams{Title: "Parent"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Child", Parent: "T01"})
+	is.NoErr(err)
+
+	_, err = store.Move(MoveTaskParams{ID: "T01", To: "T01.01"})
+	is.True(errors.Is(err, ErrInvalid)) // into its own subtree
+	_, err = store.Move(MoveTaskParams{ID: "T01", To: "T01"})
+	is.True(errors.Is(err, ErrInvalid)) // under itself
+	_, err = store.Move(MoveTaskParams{ID: "T01.01", To: "T01"})
+	is.True(errors.Is(err, ErrInvalid)) // already there
+	_, err = store.Move(MoveTaskParams{ID: "T01", To: "root"})
+	is.True(errors.Is(err, ErrInvalid)) // already there
+	_, err = store.Move(MoveTaskParams{ID: "T01.01", To: "T09"})
+	is.True(errors.Is(err, ErrNotFound))
+	_, err = store.Move(MoveTaskParams{ID: "T09", To: "root"})
+	is.True(errors.Is(err, ErrNotFound))
+
+	child, err := store.Get("T01.01")
+	is.NoErr(err)
+	is.Equal(child.Title, "Child")
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Next returns the tasks that are ready to be worked on: active "todo" tasks
+// whose dependencies are all resolved, see Workflow.resolved. They are ordered by
+// decreasing priority, then by dependency depth (tasks with fewer dependencies
+// before them come first), then by age, unless sort fields are given.
+// The other parameters filter the tasks as for List, status and archived are ignored.
+func (f *FileTaskStore) Next(params ListTasksParams) (ListResult, error) {
+	var result ListResult
+	if err := f.applyView(&params); err != nil {
+		return result, err
+	}
+	active, err := f.loadAll(".")
+	if err != nil {
+		return result, fmt.Errorf("loading tasks: %v", err)
+	}
+	archived, err := f.loadAll(archivedDir)
+	if err != nil {
+		return result, fmt.Errorf("loading archived tasks: %v", err)
+	}
+	resolved := resolvedTasks(active, archived, f.workflow)
+
+	g := newDependencyGraph(active)
+	ready := make([]Task, 0, len(active))
+	for _, t := range active {
+		if t.Status != StatusTodo {
+			continue
+		}
+		if slices.ContainsFunc(g[t.ID.String()], func(dep string) bool { return !resolved[dep] }) {
+			continue // blocked
+		}
+		ready = append(ready, t)
+	}
+
+	setProgress(ready, active, f.workflow)
+	params.Status = nil
+	params.Archived = ArchivedExclude
+	ready, err = filterTasks(ready, params, f.workflow, f.fields)
+	if err != nil {
+		return result, fmt.Errorf("filtering tasks: %w", err)
+	}
+	if len(params.Sort) > 0 {
+		sortTasks(ready, params.Sort, params.Reverse, f.fields)
+	} else {
+		depths := g.depths()
+		slices.SortStableFunc(ready, func(a, b Task) int {
+			if a.Priority != b.Priority {
+				return int(b.Priority) - int(a.Priority)
+			}
+			if da, db := depths[a.ID.String()], depths[b.ID.String()]; da != db {
+				return da - db
+			}
+			if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+				return c
+			}
+			return compareIDs(a.ID, b.ID)
+		})
+		if params.Reverse {
+			slices.Reverse(ready)
+		}
+	}
+	return Paginate(ready, params.Limit, params.Offset), nil
+}
+
+// resolvedTasks returns the IDs of the tasks that no longer block the tasks
+// depending on them: archived tasks and tasks with a resolved status.
+func resolvedTasks(active, archived []Task, w Workflow) map[string]bool {
+	resolved := make(map[string]bool, len(active)+len(archived))
+	for _, t := range archived {
+		resolved[t.ID.String()] = true
+	}
+	for _, t := range active {
+		if w.resolved(t.Status) {
+			resolved[t.ID.String()] = true
+		}
+	}
+	return resolved
+}
+
+// depths returns, for each task, the length of the longest chain of
+// dependencies leading to it. Tasks in a cycle are given the depth at which
+// the cycle was entered.
+func (g dependencyGraph) depths() map[string]int {
+	depths := make(map[string]int, len(g))
+	visiting := make(map[string]bool)
+	var depth func(string) int
+	depth = func(id string) int {
+		if d, ok := depths[id]; ok {
+			return d
+		}
+		if visiting[id] {
+			return 0
+		}
+		visiting[id] = true
+		d := 0
+		for _, dep := range g[id] {
+			if _, ok := g[dep]; ok {
+				d = max(d, depth(dep)+1)
+			}
+		}
+		visiting[id] = false
+		depths[id] = d
+		return d
+	}
+	for id := range g {
+		depth(id)
+	}
+	return depths
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestNextTasks(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Foundation", Priority: "high", Labels: []string{"backend"}},
+		{Title: "Feature", Priority: "critical", Dependencies: []string{"T01"}},
+		{Title: "Chore", Priority: "low"},
+		{Title: "Started", Priority: "critical"},
+		{Title: "Finished"},
+		{Title: "After finished", Priority: "medium", Dependencies: []string{"T05"}},
+		{Title: "Archived"},
+		{Title: "After archived", Priority: "medium", Assigned: []string{"alice"}, Dependencies: []string{"T07"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	for id, status := range map[string]string{"T04": "in-progress", "T05": "done"} {
+		task, err := store.Get(id)
+		is.NoErr(err)
+		is.NoErr(store.Update(&task, EditTaskParams{ID: id, NewStatus: &status}))
+	}
+	_, err := store.Archive(mustParseTaskID("07"))
+	is.NoErr(err)
+
+	titles := func(result ListResult) []string {
+		var titles []string
+		for _, task := range result.Tasks {
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+
+	result, err := store.Next(ListTasksParams{})
+	is.NoErr(err)
+	// Feature is blocked by Foundation, After finished comes later in its dependency chain.
+	is.Equal(titles(result), []string{"Foundation", "After archived", "After finished", "Chore"})
+
+	result, err = store.Next(ListTasksParams{Limit: 1})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Foundation"})
+	is.True(result.Pagination.HasMore)
+
+	result, err = store.Next(ListTasksParams{Assigned: []string{"alice"}})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"After archived"})
+	result, err = store.Next(ListTasksParams{Labels: []string{"backend"}, Status: []string{"done"}})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Foundation"}) // status is ignored
+
+	// Completing Foundation unblocks Feature, which has a higher priority.
+	foundation, err := store.Get("T01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&foundation, EditTaskParams{ID: "T01", NewStatus: ptr("done")}))
+	result, err = store.Next(ListTasksParams{Limit: 1})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Feature"})
+}
+
+func TestNextTasks_Workflow(t *testing.T) {
+	is := is.New(t)
+	w, err := NewWorkflow(
+		[]string{"todo", "in-progress", "shipped", "wontfix", "done"},
+		map[string][]string{
+			"todo":        {"in-progress", "wontfix"},
+			"in-progress": {"shipped"},
+			"shipped":     {},
+			"wontfix":     {},
+		},
+	)
+	is.NoErr(err)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog", WithWorkflow(w))
+	for _, p := range []CreateTaskParams{
+		{Title: "Shipped", Due: "yesterday"},
+		{Title: "Won't fix", Due: "yesterday"},
+		{Title: "Started", Due: "yesterday"},
+		{Title: "After shipped", Dependencies: []string{"T01"}},
+		{Title: "After won't fix", Dependencies: []string{"T02"}},
+		{Title: "After started", Dependencies: []string{"T03"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	for id, statuses := range map[string][]string{
+		"T01": {"in-progress", "shipped"},
+		"T02": {"wontfix"},
+		"T03": {"in-progress"},
+	} {
+		task, err := store.Get(id)
+		is.NoErr(err)
+		for _, status := range statuses {
+			is.NoErr(store.Update(&task, EditTaskParams{ID: id, NewStatus: &status}))
+		}
+	}
+
+	titles := func(result ListResult) []string {
+		var titles []string
+		for _, task := range result.Tasks {
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+	// statuses without transitions are final, they no longer block nor are overdue
+	result, err := store.Next(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"After shipped", "After won't fix"})
+	result, err = store.List(ListTasksParams{Overdue: true})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Started"})
+}
@@ -2,12 +2,18 @@ package core
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
 )
 
 var errWrongIDFormat = errors.New("wrong id format")
@@ -30,13 +36,9 @@ func parseTask(content []byte) (task Task, err error) {
 			return task, fmt.Errorf("parent task ID %q: %w", matter.Parent, err)
 		}
 	}
-	var status Status
-	if matter.Status != "" {
-		status, err = ParseStatus(matter.Status)
-		if err != nil {
-			return task, fmt.Errorf("status %q: %w", matter.Status, err)
-		}
-	}
+	// Statuses depend on the workflow: typos are corrected when the task is
+	// handed out by the index, unknown ones are reported by the conflict detector.
+	status := normalizeStatus(matter.Status)
 
 	var priority Priority
 	if matter.Priority != "" {
@@ -54,10 +56,24 @@ func parseTask(content []byte) (task Task, err error) {
 		Labels:       matter.Labels,
 		Parent:       pid,
 		Priority:     priority,
+		Start:        matter.Start,
+		Due:          matter.Due,
+		Estimate:     matter.Estimate,
+		Spent:        matter.Spent,
 		Dependencies: matter.Dependencies,
 		CreatedAt:    matter.CreatedAt,
 		UpdatedAt:    matter.UpdatedAt,
 		History:      matter.History,
+		keyOrder:     matter.keys,
+	}
+	for key, value := range matter.Fields {
+		if task.Fields == nil {
+			task.Fields = make(map[string]any, len(matter.Fields))
+		}
+		if hasTimestamp(value) {
+			task.dateFields = append(task.dateFields, key)
+		}
+		task.Fields[key] = normalizeFieldValue(value)
 	}
 
 	parseMarkdownBody(&task, content)
@@ -77,71 +93,160 @@ func parseTaskIDfromFileName(fileName string) (TaskID, error) {
 	return id, nil
 }
 
+// parseMarkdownBody reads the sections managed by backlog, the ones with the
+// level 2 headers written by Task.Bytes, from the markdown AST of the body.
+// Everything else, such as a section added by hand or text around the
+// acceptance criteria, is kept in ExtraSections to be written back unchanged,
+// the lines following a criterion in its list being kept in its Extra.
 func parseMarkdownBody(task *Task, content []byte) {
-	// This is a simplified parser. A more robust solution might use a proper markdown AST parser.
-	sections := splitByHeaders(string(content))
-
-	task.Description = getSectionContent(sections, descHeader)
-	task.ImplementationPlan = getSectionContent(sections, planHeader)
-	task.ImplementationNotes = getSectionContent(sections, notesHeader)
-
-	acContent := getSectionContent(sections, acHeader)
-	task.AcceptanceCriteria = parseAcceptanceCriteria(acContent)
-}
-
-func splitByHeaders(content string) map[string]string {
-	headers := []string{descHeader, acHeader, planHeader, notesHeader}
-	sections := make(map[string]string)
-
-	for i, header := range headers {
-		start := strings.Index(content, header)
-		if start == -1 {
+	var seen []string // managed headers, the last one being the anchor of the extra sections
+	keep := func(text string) {
+		if text = strings.Trim(text, "\n"); strings.TrimSpace(text) != "" {
+			after := ""
+			if len(seen) > 0 {
+				after = seen[len(seen)-1]
+			}
+			task.ExtraSections = append(task.ExtraSections, ExtraSection{After: after, Text: text})
+		}
+	}
+	for _, section := range splitSections(markdownBody(content)) {
+		if !slices.Contains(managedHeaders, section.header) || slices.Contains(seen, section.header) {
+			keep(section.text)
 			continue
 		}
-
-		end := len(content)
-		for j := i + 1; j < len(headers); j++ {
-			nextHeaderPos := strings.Index(content, headers[j])
-			if nextHeaderPos != -1 {
-				end = nextHeaderPos
-				break
+		seen = append(seen, section.header)
+		text := strings.TrimSpace(section.content)
+		switch section.header {
+		case descHeader:
+			task.Description = text
+		case planHeader:
+			task.ImplementationPlan = text
+		case notesHeader:
+			task.ImplementationNotes = text
+		case acHeader:
+			list, rest, _ := strings.Cut(text, acEndComment)
+			if before, after, found := strings.Cut(list, acStartComment); found {
+				if before = strings.Trim(before, "\n"); strings.TrimSpace(before) != "" {
+					task.ExtraSections = append(task.ExtraSections, ExtraSection{Before: acStartComment, Text: before})
+				}
+				list = after
+			}
+			var intro string
+			task.AcceptanceCriteria, intro = parseAcceptanceCriteria(list)
+			if intro = strings.Trim(intro, "\n"); strings.TrimSpace(intro) != "" {
+				task.ExtraSections = append(task.ExtraSections, ExtraSection{After: acStartComment, Text: intro})
 			}
+			keep(rest)
 		}
+	}
+}
 
-		sectionContent := content[start+len(header) : end]
-		sections[header] = strings.TrimSpace(sectionContent)
+// managedHeaders are the headers of the sections written by Task.Bytes, in order.
+var managedHeaders = []string{descHeader, acHeader, planHeader, notesHeader}
+
+// markdownBody returns the markdown after the front matter of a task file,
+// which is delimited by lines made of "---".
+func markdownBody(content []byte) []byte {
+	offset := 0
+	for line := range bytes.Lines(content) {
+		first := offset == 0
+		offset += len(line)
+		delimiter := string(bytes.TrimRight(line, "\r\n")) == "---"
+		if first && !delimiter {
+			return content // no front matter
+		}
+		if !first && delimiter {
+			return content[offset:]
+		}
 	}
+	return nil
+}
 
-	return sections
+// markdownSection is a part of the body starting with a level 2 header, or the
+// text before the first one, whose header is empty.
+type markdownSection struct {
+	header  string // header line, such as "## Description"
+	content string // text after the header line
+	text    string // whole text, with the header line
 }
 
-func getSectionContent(sections map[string]string, header string) string {
-	if content, ok := sections[header]; ok {
-		return content
+// splitSections splits the body at its level 2 ATX headers, found with the
+// goldmark parser so that lines looking like headers in code blocks or HTML
+// are not mistaken for them.
+func splitSections(body []byte) []markdownSection {
+	doc := goldmark.DefaultParser().Parse(text.NewReader(body))
+	var starts []int // offsets of the header lines
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		h, ok := n.(*ast.Heading)
+		if !ok || h.Level != 2 || h.Lines().Len() == 0 {
+			continue
+		}
+		start := bytes.LastIndexByte(body[:h.Lines().At(0).Start], '\n') + 1
+		if body[start] == '#' { // not a setext header
+			starts = append(starts, start)
+		}
 	}
-	return ""
+	sections := []markdownSection{}
+	if len(starts) == 0 || starts[0] > 0 {
+		end := len(body)
+		if len(starts) > 0 {
+			end = starts[0]
+		}
+		sections = append(sections, markdownSection{content: string(body[:end]), text: string(body[:end])})
+	}
+	for i, start := range starts {
+		end := len(body)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		section := string(body[start:end])
+		header, content, _ := strings.Cut(section, "\n")
+		sections = append(sections, markdownSection{
+			header:  strings.TrimSpace(header),
+			content: content,
+			text:    section,
+		})
+	}
+	return sections
 }
 
-func parseAcceptanceCriteria(content string) []AcceptanceCriterion {
-	var criteria []AcceptanceCriterion
+func parseAcceptanceCriteria(content string) (criteria []AcceptanceCriterion, intro string) {
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	re := regexp.MustCompile(`- \[( |x)\] #(\d+) (.*)`)
 
+	var lines []string // lines following the last criterion, or the intro
+	flush := func() {
+		text := strings.Join(lines, "\n")
+		lines = nil
+		if len(criteria) == 0 {
+			intro = text
+		} else if strings.TrimSpace(text) != "" {
+			criteria[len(criteria)-1].Extra = text
+		}
+	}
 	for scanner.Scan() {
 		line := scanner.Text()
 		matches := re.FindStringSubmatch(line)
 		if len(matches) == 4 {
+			flush()
 			index, _ := strconv.Atoi(matches[2])
 			criteria = append(criteria, AcceptanceCriterion{
 				Checked: matches[1] == "x",
 				Index:   index,
 				Text:    matches[3],
 			})
+		} else {
+			lines = append(lines, line)
 		}
 	}
+	// the blank lines before the end of the list are written by Task.Bytes
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	flush()
 	// Ensure ACs are sorted by index, as they might not be in order in the file.
 	sort.Slice(criteria, func(i, j int) bool {
 		return criteria[i].Index < criteria[j].Index
 	})
-	return criteria
+	return criteria, intro
 }
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Progress summarizes how far the subtasks and the acceptance criteria of a
+// task are. It is computed when tasks are read and never stored.
+type Progress struct {
+	// SubtasksDone counts the direct subtasks that are resolved in the
+	// workflow, such as done or cancelled.
+	SubtasksDone  int `json:"subtasks_done"`
+	SubtasksTotal int `json:"subtasks_total"`
+	ACChecked     int `json:"ac_checked"`
+	ACTotal       int `json:"ac_total"`
+}
+
+// Complete reports whether the task has subtasks and all of them, as well as
+// all its acceptance criteria, are complete.
+func (p Progress) Complete() bool {
+	return p.SubtasksTotal > 0 && p.SubtasksDone == p.SubtasksTotal && p.ACChecked == p.ACTotal
+}
+
+// String formats the progress for display, e.g. "3/4 subtasks, 1/2 AC".
+func (p Progress) String() string {
+	var parts []string
+	if p.SubtasksTotal > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d subtasks", p.SubtasksDone, p.SubtasksTotal))
+	}
+	if p.ACTotal > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d AC", p.ACChecked, p.ACTotal))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// taskProgress computes the progress of the task from its acceptance criteria
+// and its direct subtasks among the given tasks, those resolved in the workflow
+// counting as done.
+func taskProgress(task Task, tasks []Task, workflow Workflow) Progress {
+	var p Progress
+	for _, t := range tasks {
+		if t.Parent.Equals(task.ID) && !t.ID.Equals(task.ID) {
+			p.SubtasksTotal++
+			if workflow.resolved(t.Status) {
+				p.SubtasksDone++
+			}
+		}
+	}
+	p.ACTotal = len(task.AcceptanceCriteria)
+	for _, ac := range task.AcceptanceCriteria {
+		if ac.Checked {
+			p.ACChecked++
+		}
+	}
+	return p
+}
+
+// setProgress sets the progress of the given tasks, computed from the
+// subtasks among all the tasks. Tasks without subtasks nor acceptance
+// criteria have no progress. The effort of the tasks is set too, see setEffort.
+func setProgress(tasks []Task, all []Task, workflow Workflow) {
+	setEffort(tasks, all)
+	subtasks := make(map[string][]Task)
+	for _, t := range all {
+		if !t.Parent.IsZero() {
+			subtasks[t.Parent.String()] = append(subtasks[t.Parent.String()], t)
+		}
+	}
+	for i := range tasks {
+		p := taskProgress(tasks[i], subtasks[tasks[i].ID.String()], workflow)
+		if p.SubtasksTotal > 0 || p.ACTotal > 0 {
+			tasks[i].Progress = &p
+		}
+	}
+}
+
+// autoDoneReason explains the automatic status changes in the history of the parent tasks.
+const autoDoneReason = "all subtasks and acceptance criteria are complete"
+
+// autoDone marks the task done if it is a todo or in-progress parent task
+// whose subtasks and acceptance criteria are all complete and the workflow
+// allows it, see WithAutoDoneParents. It reports whether the status changed.
+func autoDone(task *Task, subtasks []Task, workflow Workflow) bool {
+	if task.Status != StatusTodo && task.Status != StatusInProgress {
+		return false
+	}
+	if workflow.CheckTransition(task.Status, StatusDone) != nil {
+		return false
+	}
+	if !taskProgress(*task, subtasks, workflow).Complete() {
+		return false
+	}
+	task.History = append(task.History, HistoryEntry{
+		Timestamp: time.Now().UTC(),
+		Change:    fmt.Sprintf("Status changed from %q to %q (%s)", task.Status, StatusDone, autoDoneReason),
+		Type:      "status_change",
+		Metadata: map[string]any{
+			"old_status": string(task.Status),
+			"new_status": string(StatusDone),
+			"reason":     autoDoneReason,
+		},
+	})
+	task.Status = StatusDone
+	task.UpdatedAt = time.Now().UTC()
+	return true
+}
+
+// completeParents applies autoDone to the ancestors of the task with the
+// given ID, from its parent up, stopping at the first one left unchanged. It
+// returns the parents marked done.
+func (f *FileTaskStore) completeParents(id TaskID) ([]Task, error) {
+	var completed []Task
+	for parentID := id.Parent(); parentID != nil && !parentID.IsZero(); parentID = parentID.Parent() {
+		found, err := f.findTaskFileIn(*parentID, ".")
+		if err != nil {
+			return completed, nil // subtasks of archived or missing tasks
+		}
+		active, err := f.loadAll(".")
+		if err != nil {
+			return completed, err
+		}
+		parent := found.Task
+		if !autoDone(&parent, active, f.workflow) {
+			return completed, nil
+		}
+		if err := f.writeFile(found.Path, parent); err != nil {
+			return completed, fmt.Errorf("could not write parent task %s: %w", parent.ID.Name(), err)
+		}
+		completed = append(completed, parent)
+	}
+	return completed, nil
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestProgress(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Parent", AC: []string{"Reviewed"}},
+		{Title: "Done", Parent: "T01"},
+		{Title: "Cancelled", Parent: "T01"},
+		{Title: "Todo", Parent: "T01"},
+		{Title: "Alone"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	for id, status := range map[string]string{"T01.01": "done", "T01.02": "cancelled"} {
+		task, err := store.Get(id)
+		is.NoErr(err)
+		is.NoErr(store.Update(&task, EditTaskParams{ID: id, NewStatus: &status}))
+	}
+
+	parent, err := store.Get("T01")
+	is.NoErr(err)
+	is.Equal(*parent.Progress, Progress{SubtasksDone: 2, SubtasksTotal: 3, ACChecked: 0, ACTotal: 1})
+	is.Equal(parent.Progress.String(), "2/3 subtasks, 0/1 AC")
+	is.True(!parent.Progress.Complete())
+
+	result, err := store.List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(result.Tasks[0].Progress, parent.Progress)
+	is.Equal(result.Tasks[4].Progress, nil) // no subtasks nor AC
+
+	// Not opted in: the parent stays todo.
+	todo, err := store.Get("T01.03")
+	is.NoErr(err)
+	is.NoErr(store.Update(&todo, EditTaskParams{ID: "T01.03", NewStatus: ptr("done")}))
+	is.NoErr(store.Update(&parent, EditTaskParams{ID: "T01", CheckAC: []int{1}}))
+	parent, err = store.Get("T01")
+	is.NoErr(err)
+	is.Equal(parent.Status, StatusTodo)
+	is.True(parent.Progress.Complete())
+}
+
+func TestAutoDoneParents(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog", WithAutoDoneParents(true))
+	for _, p := range []CreateTaskParams{
+		{Title: "Epic"},
+		{Title: "Story", Parent: "T01", AC: []string{"Works"}},
+		{Title: "Step 1", Parent: "T01.01"},
+		{Title: "Step 2", Parent: "T01.01"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	status := func(id string) Status {
+		task, err := store.Get(id)
+		is.NoErr(err)
+		return task.Status
+	}
+
+	for _, id := range []string{"T01.01.01", "T01.01.02"} {
+		task, err := store.Get(id)
+		is.NoErr(err)
+		is.NoErr(store.Update(&task, EditTaskParams{ID: id, NewStatus: ptr("done")}))
+	}
+	is.Equal(status("T01.01"), StatusTodo) // its AC is not checked
+
+	// Checking the last AC completes the story, which completes the epic.
+	story, err := store.Get("T01.01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&story, EditTaskParams{ID: "T01.01", CheckAC: []int{1}}))
+	is.Equal(story.Status, StatusDone)
+	is.Equal(status("T01"), StatusDone)
+
+	epic, err := store.Get("T01")
+	is.NoErr(err)
+	last := epic.History[len(epic.History)-1]
+	is.Equal(last.Type, "status_change")
+	is.Equal(last.Metadata["reason"], autoDoneReason)
+
+	// An explicit status is kept.
+	is.NoErr(store.Update(&story, EditTaskParams{ID: "T01.01", NewStatus: ptr("in-progress")}))
+	is.Equal(status("T01.01"), StatusInProgress)
+}
+
+func TestAutoDoneParentsWorkflow(t *testing.T) {
+	is := is.New(t)
+	w, err := NewWorkflow(
+		[]string{"todo", "in-progress", "shipped", "wontfix", "done"},
+		map[string][]string{
+			"todo":        {"in-progress", "wontfix"},
+			"in-progress": {"shipped", "done"},
+			"shipped":     {},
+			"wontfix":     {},
+		},
+	)
+	is.NoErr(err)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog", WithWorkflow(w), WithAutoDoneParents(true))
+	for _, p := range []CreateTaskParams{
+		{Title: "Epic"},
+		{Title: "Shipped", Parent: "T01"},
+		{Title: "Won't fix", Parent: "T01"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	epic, err := store.Get("T01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&epic, EditTaskParams{ID: "T01", NewStatus: ptr("in-progress")}))
+	wontfix, err := store.Get("T01.02")
+	is.NoErr(err)
+	is.NoErr(store.Update(&wontfix, EditTaskParams{ID: "T01.02", NewStatus: ptr("wontfix")}))
+
+	// final statuses of the workflow count as done
+	epic, err = store.Get("T01")
+	is.NoErr(err)
+	is.Equal(*epic.Progress, Progress{SubtasksDone: 1, SubtasksTotal: 2})
+	report, err := store.EffortReport(EffortReportParams{})
+	is.NoErr(err)
+	is.Equal(report.Total.Done, 1)
+
+	// the parent completed by the edit is returned with it
+	shipped, err := store.Get("T01.01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&shipped, EditTaskParams{ID: "T01.01", NewStatus: ptr("in-progress")}))
+	result, err := store.Edit(&shipped, EditTaskParams{ID: "T01.01", NewStatus: ptr("shipped")})
+	is.NoErr(err)
+	is.Equal(len(result.Parents), 1)
+	is.Equal(result.Parents[0].ID.Name(), "T01")
+	is.Equal(result.Parents[0].Status, StatusDone)
+	is.True(slices.Contains(result.Paths(store.Path), store.Path(result.Parents[0])))
+	epic, err = store.Get("T01")
+	is.NoErr(err)
+	is.Equal(epic.Status, StatusDone)
+}
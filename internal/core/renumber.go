@@ -0,0 +1,322 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// RenumberParams holds the parameters for renumbering sibling tasks.
+type RenumberParams struct {
+	// Parent is the task whose subtasks are renumbered, empty or "root" for the top-level tasks.
+	Parent string `json:"parent,omitempty"`
+	// ByPriority orders the siblings by decreasing priority, keeping the current
+	// order between tasks of the same priority.
+	ByPriority bool `json:"by_priority,omitempty"`
+	// Order lists sibling IDs in the order they should be numbered.
+	// Siblings that are not listed follow in their current order.
+	Order  []string `json:"order,omitempty"`
+	DryRun bool     `json:"dry_run,omitempty"`
+}
+
+// RenumberedTask is a task whose ID changed, or would change, in a renumbering.
+type RenumberedTask struct {
+	OldID   TaskID `json:"old_id"`
+	NewID   TaskID `json:"new_id"`
+	OldPath string `json:"old_path"`
+	Path    string `json:"path"`
+}
+
+// RenumberResult lists the renumbered tasks, each sibling followed by its subtasks.
+type RenumberResult struct {
+	Changes []RenumberedTask `json:"changes"`
+	// References are the other tasks whose dependencies on the renumbered tasks were rewritten.
+	References []Task `json:"references,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+}
+
+// Paths returns the files changed by the renumbering, given the path of a
+// task file: the new and old files of the renumbered tasks and the files of
+// the tasks whose dependencies were rewritten.
+func (r RenumberResult) Paths(path func(Task) string) []string {
+	var paths []string
+	for _, c := range r.Changes {
+		paths = append(paths, c.Path, c.OldPath)
+	}
+	for _, ref := range r.References {
+		paths = append(paths, path(ref))
+	}
+	return paths
+}
+
+// renumberSuffix is appended to the task files while they are renumbered, so
+// that a new ID can be given to a task before the task holding it moved away.
+const renumberSuffix = ".renumber"
+
+// Renumber gives consecutive IDs to the active subtasks of a task, or to the
+// top-level tasks, in their current order, by priority or in the given order.
+// Active subtasks follow their parent to its new ID, and the dependencies on
+// the renumbered tasks are rewritten. Archived and deleted tasks keep their ID,
+// and the IDs of archived and deleted siblings are never reused, they are skipped.
+func (f *FileTaskStore) Renumber(params RenumberParams) (RenumberResult, error) {
+	result := RenumberResult{DryRun: params.DryRun}
+	parentStr := params.Parent
+	if strings.EqualFold(parentStr, "root") {
+		parentStr = ""
+	}
+	parent, err := parseTaskID(parentStr)
+	if err != nil {
+		return result, fmt.Errorf("invalid parent task ID '%s': %w", params.Parent, err)
+	}
+	if params.ByPriority && len(params.Order) > 0 {
+		return result, fmt.Errorf("by priority and explicit order are mutually exclusive: %w", ErrInvalid)
+	}
+
+	unlock, err := f.lock()
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	if !parent.IsZero() {
+		if _, err := f.findTaskFileIn(parent, "."); err != nil {
+			return result, fmt.Errorf("get parent task %q: %w", params.Parent, err)
+		}
+	}
+
+	f.mu.Lock()
+	idx, err := f.index()
+	if err != nil {
+		f.mu.Unlock()
+		return result, err
+	}
+	all := idx.tasks(f.tasksDir, ".", archivedDir, trashDir)
+	f.mu.Unlock()
+
+	var siblings []indexedTask
+	reserved := make(map[int]bool)
+	for _, t := range all {
+		if !isDescendant(t.Task.ID, parent) || len(t.Task.ID.seg) != len(parent.seg)+1 {
+			continue
+		}
+		if filepath.Dir(t.Path) == f.tasksDir {
+			siblings = append(siblings, t)
+		} else {
+			reserved[t.Task.ID.seg[len(parent.seg)]] = true
+		}
+	}
+	slices.SortStableFunc(siblings, func(a, b indexedTask) int { return compareIDs(a.Task.ID, b.Task.ID) })
+
+	switch {
+	case params.ByPriority:
+		slices.SortStableFunc(siblings, func(a, b indexedTask) int { return int(b.Task.Priority) - int(a.Task.Priority) })
+	case len(params.Order) > 0:
+		if siblings, err = orderSiblings(siblings, params.Order); err != nil {
+			return result, err
+		}
+	}
+
+	n := 1
+	for _, sibling := range siblings {
+		for reserved[n] {
+			n++
+		}
+		newID := TaskID{seg: append(slices.Clone(parent.seg), n)}
+		n++
+		if newID.Equals(sibling.Task.ID) {
+			continue
+		}
+		for _, t := range all {
+			if filepath.Dir(t.Path) != f.tasksDir {
+				continue // archived and deleted subtasks keep their ID, as in Move
+			}
+			if !t.Task.ID.Equals(sibling.Task.ID) && !isDescendant(t.Task.ID, sibling.Task.ID) {
+				continue
+			}
+			renumbered := t.Task
+			renumbered.ID = rebase(t.Task.ID, sibling.Task.ID, newID)
+			result.Changes = append(result.Changes, RenumberedTask{
+				OldID:   t.Task.ID,
+				NewID:   renumbered.ID,
+				OldPath: t.Path,
+				Path:    filepath.Join(filepath.Dir(t.Path), renumbered.FileName()),
+			})
+		}
+	}
+	if params.DryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+	rewritten, err := f.applyRenumbering(result.Changes)
+	if err != nil {
+		return result, err
+	}
+	for _, t := range rewritten {
+		// Renumbered tasks depending on each other are already listed.
+		if !slices.ContainsFunc(result.Changes, func(c RenumberedTask) bool { return c.OldID.Equals(t.ID) }) {
+			result.References = append(result.References, t)
+		}
+	}
+	return result, nil
+}
+
+// applyRenumbering rewrites the references to the renumbered active tasks,
+// then renames the task files and updates the tasks according to the changes.
+// It returns the tasks whose references were rewritten. If a step fails, the
+// task files are restored to their content before the renumbering.
+func (f *FileTaskStore) applyRenumbering(changes []RenumberedTask) ([]Task, error) {
+	backup, err := f.backupRenumbering(changes)
+	if err != nil {
+		return nil, err
+	}
+	rewritten, err := f.renumberFiles(changes)
+	if err != nil {
+		if rerr := f.rollbackRenumbering(changes, backup); rerr != nil {
+			return nil, fmt.Errorf("%w (rolling back: %v)", err, rerr)
+		}
+		return nil, err
+	}
+	return rewritten, nil
+}
+
+// renumberFiles applies the changes of a renumbering, see applyRenumbering.
+func (f *FileTaskStore) renumberFiles(changes []RenumberedTask) ([]Task, error) {
+	// References are rewritten before any task is renumbered: a new ID may be
+	// the old ID of another task, so the changes cannot be applied twice.
+	idChanges := make(map[string]TaskID)
+	for _, c := range changes {
+		if filepath.Dir(c.OldPath) == f.tasksDir {
+			idChanges[c.OldID.String()] = c.NewID
+		}
+	}
+	detector := f.newConflictDetector()
+	rewritten, err := NewReferenceUpdater(detector, f).UpdateReferences(idChanges)
+	if err != nil {
+		return nil, fmt.Errorf("could not update references: %w", err)
+	}
+
+	tasks := make([]Task, len(changes))
+	for i, c := range changes {
+		task, err := detector.parseTaskFromFile(c.OldPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read task %s: %w", c.OldID, err)
+		}
+		tasks[i] = task
+	}
+	// Move the files out of the way first, the new IDs may be held by tasks
+	// that are renumbered as well.
+	for _, c := range changes {
+		if err := f.fs.Rename(c.OldPath, c.OldPath+renumberSuffix); err != nil {
+			return nil, fmt.Errorf("could not rename task file %s: %w", c.OldPath, err)
+		}
+	}
+	for i, c := range changes {
+		task := tasks[i]
+		newParent := TaskID{}
+		if p := c.NewID.Parent(); p != nil {
+			newParent = *p
+		}
+		if !task.Parent.Equals(newParent) {
+			RecordParentChange(&task, task.Parent, newParent, "renumber")
+			task.Parent = newParent
+		}
+		RecordIDChange(&task, c.OldID, c.NewID, "renumber", nil)
+		task.ID = c.NewID
+		task.UpdatedAt = time.Now().UTC()
+		if err := f.writeFile(c.Path, task); err != nil {
+			return nil, fmt.Errorf("could not write task %s: %w", c.NewID, err)
+		}
+		if err := f.fs.Remove(c.OldPath + renumberSuffix); err != nil {
+			return nil, fmt.Errorf("could not remove old file: %w", err)
+		}
+	}
+	return rewritten, nil
+}
+
+// backupRenumbering returns the content of the files a renumbering may
+// change: the renumbered tasks and the active tasks, whose references may be
+// rewritten.
+func (f *FileTaskStore) backupRenumbering(changes []RenumberedTask) (map[string][]byte, error) {
+	f.mu.Lock()
+	idx, err := f.index()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	paths := make([]string, 0, len(changes))
+	for _, t := range idx.tasks(f.tasksDir, ".") {
+		paths = append(paths, t.Path)
+	}
+	f.mu.Unlock()
+	for _, c := range changes {
+		paths = append(paths, c.OldPath)
+	}
+
+	backup := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		if _, ok := backup[path]; ok {
+			continue
+		}
+		b, err := afero.ReadFile(f.fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read task file %s: %w", path, err)
+		}
+		backup[path] = b
+	}
+	return backup, nil
+}
+
+// rollbackRenumbering restores the files changed by a failed renumbering:
+// the files set aside are moved back, the new files are removed and the
+// other files get their content back.
+func (f *FileTaskStore) rollbackRenumbering(changes []RenumberedTask, backup map[string][]byte) error {
+	var errs []error
+	for _, c := range changes {
+		if exists, _ := afero.Exists(f.fs, c.OldPath+renumberSuffix); exists {
+			errs = append(errs, f.fs.Rename(c.OldPath+renumberSuffix, c.OldPath))
+		}
+		if _, ok := backup[c.Path]; !ok {
+			if err := f.fs.Remove(c.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(backup)) {
+		if b, err := afero.ReadFile(f.fs, path); err == nil && bytes.Equal(b, backup[path]) {
+			continue
+		}
+		errs = append(errs, WriteFileAtomic(f.fs, path, backup[path], 0o644))
+	}
+	return errors.Join(errs...)
+}
+
+// orderSiblings puts the siblings with the given IDs first, in that order,
+// followed by the other siblings in their current order.
+func orderSiblings(siblings []indexedTask, order []string) ([]indexedTask, error) {
+	ordered := make([]indexedTask, 0, len(siblings))
+	for _, s := range order {
+		id, err := parseTaskID(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task ID '%s': %w", s, err)
+		}
+		i := slices.IndexFunc(siblings, func(t indexedTask) bool { return t.Task.ID.Equals(id) })
+		if i < 0 {
+			return nil, fmt.Errorf("task %s is not an active sibling or is listed twice: %w", id, ErrInvalid)
+		}
+		ordered = append(ordered, siblings[i])
+		siblings = slices.Delete(slices.Clone(siblings), i, i+1)
+	}
+	return append(ordered, siblings...), nil
+}
+
+// compareIDs orders task IDs numerically, segment by segment.
+func compareIDs(a, b TaskID) int {
+	return slices.Compare(a.seg, b.seg)
+}
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestRenumber(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Epic"},
+		{Title: "First", Parent: "T01"},
+		{Title: "Deleted", Parent: "T01"},
+		{Title: "Archived", Parent: "T01"},
+		{Title: "Fourth", Parent: "T01"},
+		{Title: "Fourth child", Parent: "T01.04"},
+		{Title: "Dependent", Dependencies: []string{"T01.04", "T01.04.01"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	_, err := store.Delete(DeleteTaskParams{ID: "T01.02"})
+	is.NoErr(err)
+	_, err = store.Archive(mustParseTaskID("01.03"))
+	is.NoErr(err)
+
+	// The IDs of the archived and deleted tasks are not reused.
+	result, err := store.Renumber(RenumberParams{Parent: "T01", ByPriority: true, DryRun: true})
+	is.NoErr(err)
+	is.Equal(len(result.Changes), 0)
+
+	// First goes after Fourth, which takes its ID.
+	result, err = store.Renumber(RenumberParams{Parent: "T01", Order: []string{"T01.04"}, DryRun: true})
+	is.NoErr(err)
+	is.True(result.DryRun)
+	is.Equal(len(result.Changes), 3)
+	is.Equal(result.Changes[0].OldID.String(), "01.04")
+	is.Equal(result.Changes[0].NewID.String(), "01.01")
+	is.Equal(result.Changes[1].OldID.String(), "01.04.01")
+	is.Equal(result.Changes[1].NewID.String(), "01.01.01")
+	is.Equal(result.Changes[2].OldID.String(), "01.01")
+	is.Equal(result.Changes[2].NewID.String(), "01.04")
+	for _, c := range result.Changes {
+		exists, err := afero.Exists(fs, c.OldPath)
+		is.NoErr(err)
+		is.True(exists) // nothing changed in a dry run
+	}
+
+	_, err = store.Renumber(RenumberParams{Parent: "T01", Order: []string{"T01.04"}})
+	is.NoErr(err)
+	first, err := store.Get("T01.04")
+	is.NoErr(err)
+	is.Equal(first.Title, "First")
+	fourth, err := store.Get("T01.01")
+	is.NoErr(err)
+	is.Equal(fourth.Title, "Fourth")
+	original, ok := fourth.GetOriginalID()
+	is.True(ok)
+	is.Equal(original.String(), "01.04")
+	child, err := store.Get("T01.01.01")
+	is.NoErr(err)
+	is.Equal(child.Title, "Fourth child")
+	is.Equal(child.Parent.String(), "01.01")
+	dependent, err := store.Get("T02")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T01.01", "T01.01.01"})
+}
+
+func TestRenumber_ArchivedSubtasks(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Epic"},
+		{Title: "First", Parent: "T01"},
+		{Title: "Second", Parent: "T01"},
+		{Title: "First archived", Parent: "T01.01"},
+		{Title: "Second archived", Parent: "T01.02"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	for _, id := range []string{"01.01.01", "01.02.01"} {
+		_, err := store.Archive(mustParseTaskID(id))
+		is.NoErr(err)
+	}
+
+	// Only the active siblings swap their IDs, the archived subtasks keep theirs.
+	result, err := store.Renumber(RenumberParams{Parent: "T01", Order: []string{"T01.02"}})
+	is.NoErr(err)
+	is.Equal(len(result.Changes), 2)
+	for _, c := range result.Changes {
+		is.Equal(filepath.Dir(c.Path), ".backlog")
+	}
+	second, err := store.Get("T01.01")
+	is.NoErr(err)
+	is.Equal(second.Title, "Second")
+	for id, title := range map[string]string{"01.01.01": "First archived", "01.02.01": "Second archived"} {
+		archived, err := store.findTaskFileIn(mustParseTaskID(id), archivedDir)
+		is.NoErr(err)
+		is.Equal(archived.Task.Title, title)
+	}
+}
+
+func TestRenumber_Compact(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, title := range []string{"One", "Two", "Three", "Four"} {
+		_, err := store.Create(CreateTaskParams{Title: title})
+		is.NoErr(err)
+	}
+	_, err := store.Delete(DeleteTaskParams{ID: "T02"})
+	is.NoErr(err)
+	_, err = store.EmptyTrash()
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Four child", Parent: "T04"})
+	is.NoErr(err)
+
+	result, err := store.Renumber(RenumberParams{Parent: "root"})
+	is.NoErr(err)
+	is.Equal(len(result.Changes), 3) // T03, T04 and T04.01
+	listResult, err := store.List(ListTasksParams{})
+	is.NoErr(err)
+	var ids []string
+	for _, task := range listResult.Tasks {
+		ids = append(ids, task.ID.String())
+	}
+	is.Equal(ids, []string{"01", "02", "03", "03.01"})
+
+	_, err = store.Renumber(RenumberParams{ByPriority: true, Order: []string{"T01"}})
+	is.True(errors.Is(err, ErrInvalid))
+	_, err = store.Renumber(RenumberParams{Order: []string{"T03.01"}})
+	is.True(errors.Is(err, ErrInvalid)) // not a sibling
+	_, err = store.Renumber(RenumberParams{Parent: "T09"})
+	is.True(errors.Is(err, ErrNotFound))
+}
+
+// failingRenameFs fails the renames to the given path.
+type failingRenameFs struct {
+	afero.Fs
+	path string
+}
+
+func (fs failingRenameFs) Rename(oldname, newname string) error {
+	if newname == fs.path {
+		return errors.New("disk full")
+	}
+	return fs.Fs.Rename(oldname, newname)
+}
+
+func TestRenumber_Rollback(t *testing.T) {
+	is := is.New(t)
+	mem := afero.NewMemMapFs()
+	store := NewFileTaskStore(mem, ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "First"},
+		{Title: "Second"},
+		{Title: "Second child", Parent: "T02"},
+		{Title: "Dependent", Dependencies: []string{"T02", "T02.01"}},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	before := make(map[string][]byte)
+	files, err := afero.ReadDir(mem, ".backlog")
+	is.NoErr(err)
+	for _, file := range files {
+		if !file.IsDir() && isTaskFileName(file.Name()) {
+			before[file.Name()], err = afero.ReadFile(mem, filepath.Join(".backlog", file.Name()))
+			is.NoErr(err)
+		}
+	}
+
+	// Writing the renumbered subtask fails after the references were
+	// rewritten and the files set aside.
+	failing := NewFileTaskStore(failingRenameFs{Fs: mem, path: filepath.Join(".backlog", "T01.01-second_child.md")}, ".backlog")
+	_, err = failing.Renumber(RenumberParams{Order: []string{"T02"}})
+	is.True(err != nil)
+
+	files, err = afero.ReadDir(mem, ".backlog")
+	is.NoErr(err)
+	after := make(map[string][]byte)
+	for _, file := range files {
+		is.True(!strings.HasSuffix(file.Name(), renumberSuffix)) // no file left aside
+		if !file.IsDir() && isTaskFileName(file.Name()) {
+			after[file.Name()], err = afero.ReadFile(mem, filepath.Join(".backlog", file.Name()))
+			is.NoErr(err)
+		}
+	}
+	is.Equal(after, before) // rolled back
+
+	result, err := store.Renumber(RenumberParams{Order: []string{"T02"}})
+	is.NoErr(err)
+	is.Equal(len(result.Changes), 3)
+	is.Equal(len(result.References), 1)
+	is.Equal(result.References[0].Title, "Dependent")
+	dependent, err := store.Get("T03")
+	is.NoErr(err)
+	is.Equal(dependent.Dependencies.ToSlice(), []string{"T01", "T01.01"})
+}
@@ -0,0 +1,265 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agnivade/levenshtein"
+)
+
+// SearchParams holds the parameters for searching tasks.
+type SearchParams struct {
+	Query string `json:"query" jsonschema:"Required. The words to search for, typos are tolerated."`
+	// Archived selects whether archived tasks are excluded (default), included or the only ones searched.
+	Archived ArchivedMode `json:"archived,omitempty" jsonschema:"Archived tasks: 'exclude' (default), 'include' or 'only'."`
+	Limit    int          `json:"limit,omitempty"    jsonschema:"Maximum number of results to return (0 means no limit)."`
+	Offset   int          `json:"offset,omitempty"   jsonschema:"Number of results to skip from the beginning."`
+}
+
+// SearchHit is a task matching a search, with its relevance.
+type SearchHit struct {
+	Task  Task    `json:"task"`
+	Score float64 `json:"score"`
+	// Snippet is an excerpt of the task around the matches, highlighted with **.
+	Snippet string `json:"snippet"`
+}
+
+// SearchResult holds the tasks matching a search, the most relevant first.
+type SearchResult struct {
+	Hits  []SearchHit `json:"hits"`
+	Total int         `json:"total"` // before limit and offset
+}
+
+// searchField is a text field of the tasks searched, with its weight in the score.
+type searchField struct {
+	boost float64
+	text  func(Task) string
+}
+
+var searchFields = []searchField{
+	{3, func(t Task) string { return t.Title }}, // the title must stay first, see snippet
+	{1.5, func(t Task) string {
+		texts := make([]string, len(t.AcceptanceCriteria))
+		for i, ac := range t.AcceptanceCriteria {
+			texts[i] = ac.Text
+		}
+		return strings.Join(texts, "\n")
+	}},
+	{1, func(t Task) string { return t.Description }},
+	{0.5, func(t Task) string { return t.ImplementationPlan }},
+	{0.5, func(t Task) string { return t.ImplementationNotes }},
+}
+
+// BM25 parameters: term frequency saturation and length normalization.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+func tokenize(s string) []string {
+	return wordRegex.FindAllString(strings.ToLower(s), -1)
+}
+
+// searchDoc holds the term frequencies of each field of a task.
+type searchDoc struct {
+	task   Task
+	terms  []map[string]int // by field
+	length []int            // by field
+}
+
+// Search returns the tasks matching the words of the query, ranked with BM25
+// over their title, acceptance criteria, description, plan and notes, the
+// title weighing the most. Words also match the words they prefix and, with
+// a lower score, words at a small edit distance, so typos still find tasks.
+func (f *FileTaskStore) Search(params SearchParams) (SearchResult, error) {
+	result := SearchResult{Hits: []SearchHit{}}
+	queryTerms := tokenize(params.Query)
+	if len(queryTerms) == 0 {
+		return result, fmt.Errorf("empty search query: %w", ErrInvalid)
+	}
+	dirs, err := params.Archived.dirs()
+	if err != nil {
+		return result, err
+	}
+	tasks, err := f.loadAll(dirs...)
+	if err != nil {
+		return result, fmt.Errorf("loading tasks: %v", err)
+	}
+	setProgress(tasks, tasks, f.workflow)
+
+	docs := make([]searchDoc, len(tasks))
+	// average length of the non-empty fields
+	avgLength := make([]float64, len(searchFields))
+	nonEmpty := make([]int, len(searchFields))
+	docFreq := make(map[string]int)
+	for i, t := range tasks {
+		doc := searchDoc{task: t, terms: make([]map[string]int, len(searchFields)), length: make([]int, len(searchFields))}
+		seen := make(map[string]bool)
+		for j, field := range searchFields {
+			doc.terms[j] = make(map[string]int)
+			words := tokenize(field.text(t))
+			for _, w := range words {
+				doc.terms[j][w]++
+				if !seen[w] {
+					seen[w] = true
+					docFreq[w]++
+				}
+			}
+			doc.length[j] = len(words)
+			avgLength[j] += float64(len(words))
+			if len(words) > 0 {
+				nonEmpty[j]++
+			}
+		}
+		docs[i] = doc
+	}
+	for j := range avgLength {
+		avgLength[j] /= float64(max(nonEmpty[j], 1))
+	}
+
+	// Each query term matches the terms of the vocabulary it expands to.
+	expansions := make([]map[string]float64, len(queryTerms))
+	for i, q := range queryTerms {
+		expansions[i] = expandTerm(q, docFreq)
+	}
+
+	n := float64(len(docs))
+	for _, doc := range docs {
+		score := 0.0
+		matched := make(map[string]bool)
+		for _, expansion := range expansions {
+			best := 0.0
+			for term, weight := range expansion {
+				tf := 0.0
+				for j, field := range searchFields {
+					if c := doc.terms[j][term]; c > 0 {
+						norm := 1 - bm25B + bm25B*float64(doc.length[j])/math.Max(avgLength[j], 1)
+						tf += field.boost * float64(c) / norm
+					}
+				}
+				if tf == 0 {
+					continue
+				}
+				matched[term] = true
+				df := float64(docFreq[term])
+				idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+				best = max(best, weight*idf*tf*(bm25K1+1)/(tf+bm25K1))
+			}
+			score += best
+		}
+		if score > 0 {
+			result.Hits = append(result.Hits, SearchHit{Task: doc.task, Score: math.Round(score*1000) / 1000, Snippet: snippet(doc.task, matched)})
+		}
+	}
+	slices.SortStableFunc(result.Hits, func(a, b SearchHit) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return compareIDs(a.Task.ID, b.Task.ID)
+	})
+
+	result.Total = len(result.Hits)
+	start := min(max(params.Offset, 0), len(result.Hits))
+	end := len(result.Hits)
+	if params.Limit > 0 {
+		end = min(start+params.Limit, end)
+	}
+	result.Hits = result.Hits[start:end]
+	return result, nil
+}
+
+// expandTerm returns the terms of the vocabulary matched by a query term with
+// their weight: 1 for the term itself, less for the terms it prefixes and
+// less again for the terms within a small edit distance.
+func expandTerm(q string, vocabulary map[string]int) map[string]float64 {
+	expansion := make(map[string]float64)
+	maxDistance := 0
+	switch qlen := len([]rune(q)); {
+	case qlen > 6:
+		maxDistance = 2
+	case qlen > 3:
+		maxDistance = 1
+	}
+	for term := range vocabulary {
+		switch {
+		case term == q:
+			expansion[term] = 1
+		case len(q) >= 3 && strings.HasPrefix(term, q):
+			expansion[term] = 0.8
+		case maxDistance > 0 && abs(len(term)-len(q)) <= maxDistance:
+			if d := levenshtein.ComputeDistance(q, term); d <= maxDistance {
+				expansion[term] = 0.7 - 0.2*float64(d-1)
+			}
+		}
+	}
+	return expansion
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// The number of words shown before and from the first match of a snippet.
+const (
+	snippetWordsBefore = 6
+	snippetWordsAfter  = 12
+)
+
+// snippet returns an excerpt of the body field with the most matched words,
+// or of the title, with the matched words highlighted.
+func snippet(t Task, matched map[string]bool) string {
+	bestText, bestCount := t.Title, 0
+	for _, field := range searchFields[1:] {
+		text := field.text(t)
+		count := 0
+		for _, w := range tokenize(text) {
+			if matched[w] {
+				count++
+			}
+		}
+		if count > bestCount {
+			bestText, bestCount = text, count
+		}
+	}
+	text := strings.Join(strings.Fields(bestText), " ")
+	words := wordRegex.FindAllStringIndex(text, -1)
+	first := slices.IndexFunc(words, func(w []int) bool { return matched[strings.ToLower(text[w[0]:w[1]])] })
+	if first < 0 {
+		first = 0
+	}
+	from, to := 0, len(text)
+	if i := first - snippetWordsBefore; i > 0 {
+		from = words[i][0]
+	}
+	if i := first + snippetWordsAfter; i < len(words) {
+		to = words[i][0]
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, w := range words {
+		if w[0] < from || w[1] > to || !matched[strings.ToLower(text[w[0]:w[1]])] {
+			continue
+		}
+		b.WriteString(text[pos:w[0]])
+		b.WriteString("**" + text[w[0]:w[1]] + "**")
+		pos = w[1]
+	}
+	b.WriteString(strings.TrimRight(text[pos:to], " "))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestSearch(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Fix login redirect", Description: "After the login, users land on a blank page instead of the dashboard."},
+		{Title: "Dashboard charts", Description: "Show the weekly charts. The login page is out of scope."},
+		{Title: "Authentication", AC: []string{"Users can login with a password", "Users can reset their password"}},
+		{Title: "Release notes"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	titles := func(result SearchResult) []string {
+		var titles []string
+		for _, hit := range result.Hits {
+			titles = append(titles, hit.Task.Title)
+		}
+		return titles
+	}
+
+	result, err := store.Search(SearchParams{Query: "login"})
+	is.NoErr(err)
+	// The title weighs more than the acceptance criteria, which weigh more than the description.
+	is.Equal(titles(result), []string{"Fix login redirect", "Authentication", "Dashboard charts"})
+	is.Equal(result.Total, 3)
+	is.True(result.Hits[0].Score > result.Hits[1].Score)
+	is.Equal(result.Hits[0].Snippet, "After the **login**, users land on a blank page instead of the dashboard.")
+	is.Equal(result.Hits[1].Snippet, "Users can **login** with a password Users can reset their password")
+
+	result, err = store.Search(SearchParams{Query: "pasword"}) // typo
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Authentication"})
+	is.Equal(result.Hits[0].Snippet, "Users can login with a **password** Users can reset their **password**")
+
+	result, err = store.Search(SearchParams{Query: "dash"}) // prefix
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Dashboard charts", "Fix login redirect"})
+
+	result, err = store.Search(SearchParams{Query: "login", Limit: 1, Offset: 1})
+	is.NoErr(err)
+	is.Equal(titles(result), []string{"Authentication"})
+	is.Equal(result.Total, 3)
+
+	result, err = store.Search(SearchParams{Query: "login redirect"})
+	is.NoErr(err)
+	is.Equal(titles(result)[0], "Fix login redirect") // matches both words
+
+	result, err = store.Search(SearchParams{Query: "kubernetes"})
+	is.NoErr(err)
+	is.Equal(len(result.Hits), 0)
+
+	_, err = store.Search(SearchParams{Query: " ? "})
+	is.True(errors.Is(err, ErrInvalid))
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/agnivade/levenshtein"
@@ -19,26 +20,168 @@ const (
 	StatusRejected   Status = "rejected"
 )
 
-var statuses = []string{
-	string(StatusTodo),
-	string(StatusInProgress),
-	string(StatusDone),
-	string(StatusCancelled),
-	string(StatusArchived),
-	string(StatusRejected),
+var statuses = []Status{
+	StatusTodo,
+	StatusInProgress,
+	StatusDone,
+	StatusCancelled,
+	StatusArchived,
+	StatusRejected,
 }
-var allStatuses = strings.Join(statuses, ",")
 
+// ParseStatus parses a status of the default workflow, tolerating typos.
 func ParseStatus(s string) (Status, error) {
+	return DefaultWorkflow().Parse(s)
+}
+
+// normalizeStatus returns the status as written in task files: lower case, words joined with '-'.
+func normalizeStatus(s string) Status {
+	return Status(strings.Join(strings.Fields(strings.ToLower(s)), "-"))
+}
+
+// Workflow defines the statuses of the tasks and the transitions allowed between them.
+type Workflow struct {
+	Statuses []Status
+	// Transitions lists the statuses each status can change to.
+	// Statuses without an entry can change to any status.
+	Transitions map[Status][]Status
+}
+
+// DefaultWorkflow returns the built-in statuses, without transition rules.
+func DefaultWorkflow() Workflow {
+	return Workflow{Statuses: slices.Clone(statuses)}
+}
+
+// NewWorkflow returns a workflow with the given statuses and transitions,
+// the default one if there are no statuses. The statuses must include todo,
+// the status of new tasks, and done, the status of completed tasks. The
+// archived status is added if missing since archiving is not a transition.
+func NewWorkflow(names []string, transitions map[string][]string) (Workflow, error) {
+	w := DefaultWorkflow()
+	if len(names) > 0 {
+		w.Statuses = nil
+		for _, name := range names {
+			status := normalizeStatus(name)
+			if status == "" || slices.Contains(w.Statuses, status) {
+				return Workflow{}, fmt.Errorf("status %q is empty or duplicated: %w", name, ErrInvalid)
+			}
+			w.Statuses = append(w.Statuses, status)
+		}
+		for _, required := range []Status{StatusTodo, StatusDone} {
+			if !slices.Contains(w.Statuses, required) {
+				return Workflow{}, fmt.Errorf("statuses must include %q: %w", required, ErrInvalid)
+			}
+		}
+		if !slices.Contains(w.Statuses, StatusArchived) {
+			w.Statuses = append(w.Statuses, StatusArchived)
+		}
+	}
+	if len(transitions) > 0 {
+		w.Transitions = make(map[Status][]Status, len(transitions))
+	}
+	for from, tos := range transitions {
+		fromStatus := normalizeStatus(from)
+		if !w.Known(fromStatus) {
+			return Workflow{}, fmt.Errorf("transitions from unknown status %q: %w", from, ErrInvalid)
+		}
+		allowed := []Status{}
+		for _, to := range tos {
+			toStatus := normalizeStatus(to)
+			if !w.Known(toStatus) {
+				return Workflow{}, fmt.Errorf("transition from %q to unknown status %q: %w", from, to, ErrInvalid)
+			}
+			allowed = append(allowed, toStatus)
+		}
+		w.Transitions[fromStatus] = allowed
+	}
+	return w, nil
+}
+
+// Known reports whether the status is one of the workflow.
+func (w Workflow) Known(s Status) bool {
+	return slices.Contains(w.Statuses, s)
+}
+
+// Names returns the statuses of the workflow as strings.
+func (w Workflow) Names() []string {
+	names := make([]string, len(w.Statuses))
+	for i, s := range w.Statuses {
+		names[i] = string(s)
+	}
+	return names
+}
+
+// Parse returns the status of the workflow written s, tolerating typos of
+// less than three edits to a single closest status. The empty string is the
+// todo status.
+func (w Workflow) Parse(s string) (Status, error) {
 	if s == "" {
 		return StatusTodo, nil
 	}
+	if status := normalizeStatus(s); w.Known(status) {
+		return status, nil
+	}
+	// The closest status wins, a typo as close to two statuses is ambiguous.
 	sc := strings.ReplaceAll(strings.ToLower(s), " ", "")
-	for _, validStatus := range statuses {
-		distance := levenshtein.ComputeDistance(sc, validStatus)
-		if distance < 3 {
-			return Status(validStatus), nil
+	var closest []Status
+	best := 3
+	for _, validStatus := range w.Statuses {
+		distance := levenshtein.ComputeDistance(sc, string(validStatus))
+		switch {
+		case distance < best:
+			best, closest = distance, []Status{validStatus}
+		case distance == best:
+			closest = append(closest, validStatus)
 		}
 	}
-	return "", fmt.Errorf("only valid statuses are %q: %q %w", allStatuses, s, ErrInvalid)
+	switch len(closest) {
+	case 0:
+		return "", fmt.Errorf("only valid statuses are %q: %q %w", strings.Join(w.Names(), ","), s, ErrInvalid)
+	case 1:
+		return closest[0], nil
+	default:
+		names := Workflow{Statuses: closest}.Names()
+		return "", fmt.Errorf("status %q is ambiguous, it could be %q: %w", s, strings.Join(names, ","), ErrInvalid)
+	}
+}
+
+// correct returns the status of the workflow that a status read from a task
+// file stands for, tolerating the typos Parse tolerates, e.g. in files written
+// by hand. Unknown statuses are kept for the conflict detector to report them.
+func (w Workflow) correct(s Status) Status {
+	if s == "" || w.Known(s) {
+		return s
+	}
+	if status, err := w.Parse(string(s)); err == nil {
+		return status
+	}
+	return s
+}
+
+// resolved reports whether a task with the status needs no more work: done,
+// cancelled and archived tasks, and tasks whose status cannot change to
+// another one in the workflow, such as "shipped" or "wontfix".
+func (w Workflow) resolved(s Status) bool {
+	if s == StatusDone || s == StatusCancelled || s == StatusArchived {
+		return true
+	}
+	allowed, ok := w.Transitions[s]
+	return ok && len(allowed) == 0
+}
+
+// CheckTransition returns an error if a task cannot change from one status to
+// another. Archiving and unarchiving are always allowed.
+func (w Workflow) CheckTransition(from, to Status) error {
+	if from == to || from == StatusArchived || to == StatusArchived {
+		return nil
+	}
+	allowed, ok := w.Transitions[from]
+	if !ok || slices.Contains(allowed, to) {
+		return nil
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("status cannot change from %q: %w", from, ErrInvalid)
+	}
+	names := Workflow{Statuses: allowed}.Names()
+	return fmt.Errorf("status cannot change from %q to %q, only to %q: %w", from, to, strings.Join(names, ","), ErrInvalid)
 }
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestNewWorkflow(t *testing.T) {
+	is := is.New(t)
+
+	w, err := NewWorkflow(nil, nil)
+	is.NoErr(err)
+	is.Equal(w.Names(), DefaultWorkflow().Names()) // default workflow
+
+	w, err = NewWorkflow([]string{"todo", "In Progress", "review", "done"}, map[string][]string{"review": {"in-progress", "done"}})
+	is.NoErr(err)
+	is.Equal(w.Names(), []string{"todo", "in-progress", "review", "done", "archived"})
+	is.Equal(w.Transitions[StatusInProgress], nil)
+	is.Equal(w.Transitions["review"], []Status{StatusInProgress, StatusDone})
+
+	for _, tc := range []struct {
+		statuses    []string
+		transitions map[string][]string
+	}{
+		{statuses: []string{"todo", "review"}},                 // no done
+		{statuses: []string{"in-progress", "done"}},            // no todo
+		{statuses: []string{"todo", "done", "todo"}},           // duplicated
+		{transitions: map[string][]string{"review": {"done"}}}, // unknown from
+		{transitions: map[string][]string{"todo": {"review"}}}, // unknown to
+	} {
+		_, err := NewWorkflow(tc.statuses, tc.transitions)
+		is.True(errors.Is(err, ErrInvalid))
+	}
+}
+
+func TestWorkflowParse(t *testing.T) {
+	is := is.New(t)
+	w, err := NewWorkflow([]string{"todo", "doing", "done"}, nil)
+	is.NoErr(err)
+
+	status, err := w.Parse("doing")
+	is.NoErr(err)
+	is.Equal(status, Status("doing")) // exact match before typos, done is close
+	status, err = w.Parse("")
+	is.NoErr(err)
+	is.Equal(status, StatusTodo)
+	status, err = w.Parse("dnoe")
+	is.NoErr(err)
+	is.Equal(status, StatusDone)
+	_, err = w.Parse("in-progress")
+	is.True(errors.Is(err, ErrInvalid))
+
+	// the closest status wins, ties are ambiguous
+	w, err = NewWorkflow([]string{"todo", "revise", "review", "done"}, nil)
+	is.NoErr(err)
+	status, err = w.Parse("reviw")
+	is.NoErr(err)
+	is.Equal(status, Status("review")) // one edit away, revise is two
+	_, err = w.Parse("revie")
+	is.True(errors.Is(err, ErrInvalid)) // one edit away from both
+}
+
+func TestWorkflowTransitions(t *testing.T) {
+	is := is.New(t)
+	w, err := NewWorkflow(
+		[]string{"todo", "in-progress", "review", "blocked", "done", "cancelled"},
+		map[string][]string{
+			"todo":        {"in-progress", "blocked", "cancelled"},
+			"in-progress": {"review", "blocked"},
+			"review":      {"in-progress", "done"},
+			"done":        {},
+		},
+	)
+	is.NoErr(err)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog", WithWorkflow(w))
+	task, err := store.Create(CreateTaskParams{Title: "Workflow"})
+	is.NoErr(err)
+
+	change := func(status string) error {
+		return store.Update(&task, EditTaskParams{ID: task.ID.String(), NewStatus: &status})
+	}
+	is.True(errors.Is(change("done"), ErrInvalid)) // done only from review
+	is.Equal(task.Status, StatusTodo)
+	is.NoErr(change("in-progress"))
+	is.NoErr(change("review"))
+	is.NoErr(change("done"))
+	is.True(errors.Is(change("todo"), ErrInvalid)) // done is final
+
+	// archiving is not a transition
+	_, err = store.Archive(task.ID)
+	is.NoErr(err)
+	task, _, err = store.Unarchive(task.ID)
+	is.NoErr(err)
+	is.Equal(task.Status, StatusDone)
+
+	// custom statuses are kept in the task files
+	is.NoErr(store.Update(&task, EditTaskParams{ID: task.ID.String(), NewTitle: ptr("Workflow again")}))
+	other, err := store.Create(CreateTaskParams{Title: "Blocked"})
+	is.NoErr(err)
+	is.NoErr(store.Update(&other, EditTaskParams{ID: other.ID.String(), NewStatus: ptr("blocked")}))
+	other, err = store.Get(other.ID.String())
+	is.NoErr(err)
+	is.Equal(other.Status, Status("blocked"))
+
+	result, err := store.List(ListTasksParams{Where: "status:blocked"})
+	is.NoErr(err)
+	is.Equal(len(result.Tasks), 1)
+
+	// the default workflow reports them
+	detector := NewConflictDetector(store.fs, store.tasksDir)
+	conflicts, err := detector.DetectConflicts()
+	is.NoErr(err)
+	is.Equal(SummarizeConflicts(conflicts).UnknownStatuses, 1)
+	detector.SetWorkflow(w)
+	conflicts, err = detector.DetectConflicts()
+	is.NoErr(err)
+	is.Equal(len(conflicts), 0)
+}
+
+func TestWorkflowStatusTypos(t *testing.T) {
+	is := is.New(t)
+	w, err := NewWorkflow([]string{"todo", "in-progress", "review", "done"}, nil)
+	is.NoErr(err)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog", WithWorkflow(w))
+
+	// statuses written by hand or by older versions of backlog
+	typos := map[string]Status{"in progres": StatusInProgress, "Done ": StatusDone, "reveiw": "review"}
+	for typo := range typos {
+		task, err := store.Create(CreateTaskParams{Title: typo})
+		is.NoErr(err)
+		b, err := afero.ReadFile(fs, store.Path(task))
+		is.NoErr(err)
+		b = bytes.Replace(b, []byte("status: todo"), []byte(fmt.Sprintf("status: %q", typo)), 1)
+		is.NoErr(afero.WriteFile(fs, store.Path(task), b, 0o644))
+	}
+
+	result, err := store.List(ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(len(result.Tasks), len(typos))
+	for _, task := range result.Tasks {
+		is.Equal(task.Status, typos[task.Title])
+	}
+	result, err = store.List(ListTasksParams{Status: []string{"review"}})
+	is.NoErr(err)
+	is.Equal(len(result.Tasks), 1)
+
+	detector := NewConflictDetector(fs, ".backlog")
+	detector.SetWorkflow(w)
+	conflicts, err := detector.DetectConflicts()
+	is.NoErr(err)
+	is.Equal(len(conflicts), 0) // the typos are not unknown statuses
+}
@@ -1,11 +1,13 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
-	"strings"
+	"sync"
 
 	"github.com/spf13/afero"
+	"github.com/veggiemonk/backlog/internal/logging"
 )
 
 const (
@@ -17,16 +19,64 @@ const (
 	acEndComment   = "<!-- AC:END -->"
 )
 
+// archivedDir is the directory, relative to the tasks directory, holding archived tasks.
+const archivedDir = "archived"
+
 type FileTaskStore struct {
 	fs       afero.Fs
 	tasksDir string
+
+	mu  sync.Mutex // guards idx
+	idx *taskIndex // lazily loaded, see index()
+
+	writeMu sync.Mutex // held along with the lock file, see lock()
+
+	autoDoneParents bool       // see WithAutoDoneParents
+	workflow        Workflow   // see WithWorkflow
+	fields          []FieldDef // see WithFields
+}
+
+// StoreOption configures a FileTaskStore.
+type StoreOption func(*FileTaskStore)
+
+// WithAutoDoneParents makes the store mark a parent task done, and record it
+// in its history, once all its subtasks and acceptance criteria are complete.
+func WithAutoDoneParents(enabled bool) StoreOption {
+	return func(f *FileTaskStore) { f.autoDoneParents = enabled }
 }
 
-func NewFileTaskStore(fs afero.Fs, tasksDir string) *FileTaskStore {
-	return &FileTaskStore{
+// WithWorkflow sets the statuses of the tasks and the transitions allowed
+// between them, the default workflow having no transition rules.
+func WithWorkflow(w Workflow) StoreOption {
+	return func(f *FileTaskStore) { f.workflow = w }
+}
+
+// WithFields declares the custom fields of the tasks. Undeclared fields found
+// in the task files are kept but cannot be set, filtered or sorted on.
+func WithFields(defs []FieldDef) StoreOption {
+	return func(f *FileTaskStore) { f.fields = defs }
+}
+
+func NewFileTaskStore(fs afero.Fs, tasksDir string, opts ...StoreOption) *FileTaskStore {
+	f := &FileTaskStore{
 		fs:       fs,
 		tasksDir: tasksDir,
+		workflow: DefaultWorkflow(),
+	}
+	for _, opt := range opts {
+		opt(f)
 	}
+	return f
+}
+
+// Workflow returns the statuses of the tasks and the transitions allowed between them.
+func (f *FileTaskStore) Workflow() Workflow {
+	return f.workflow
+}
+
+// Fields returns the custom fields declared for the tasks, sorted by name.
+func (f *FileTaskStore) Fields() []FieldDef {
+	return f.fields
 }
 
 func (f *FileTaskStore) Path(t Task) string {
@@ -34,35 +84,64 @@ func (f *FileTaskStore) Path(t Task) string {
 }
 
 func (f *FileTaskStore) write(task Task) error {
-	// Create the tasks directory if it doesn't exist
-	if err := f.fs.MkdirAll(f.tasksDir, 0o755); err != nil {
+	return f.writeFile(f.Path(task), task)
+}
+
+// writeFile writes the task to the given path, which may differ from Path(task)
+// for tasks stored outside the top-level tasks directory.
+func (f *FileTaskStore) writeFile(filePath string, task Task) error {
+	// Create the directory if it doesn't exist
+	if err := f.fs.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
 		return err
 	}
+	f.setDateFields(&task)
 	fullContent := task.Bytes()
-	filePath := f.Path(task)
-	if err := afero.WriteFile(f.fs, filePath, fullContent, 0o644); err != nil {
-		return err
+	return WriteFileAtomic(f.fs, filePath, fullContent, 0o644)
+}
+
+// index returns the task index, loaded from disk on first use and refreshed
+// against the files in the tasks directory. The caller must hold f.mu.
+func (f *FileTaskStore) index() (*taskIndex, error) {
+	if f.idx == nil {
+		f.idx = loadTaskIndex(f.fs, f.tasksDir)
+		f.idx.workflow = f.workflow
+	}
+	if err := f.idx.refresh(f.fs, f.tasksDir); err != nil {
+		return nil, fmt.Errorf("refresh task index: %w", err)
 	}
-	return nil
+	if err := f.idx.save(f.fs, f.tasksDir); err != nil {
+		// The index is only a cache, failing to persist it is not fatal.
+		logging.Warn("could not save task index", "dir", f.tasksDir, "error", err)
+	}
+	return f.idx, nil
 }
 
 // getNextTaskID finds the next available task ID in the tasks directory.
+// IDs of archived and deleted tasks are taken into account so that they are
+// never reused.
 func (f *FileTaskStore) getNextTaskID(treePath ...int) (TaskID, error) {
-	files, err := afero.ReadDir(f.fs, f.tasksDir)
+	f.mu.Lock()
+	idx, err := f.index()
 	if err != nil {
+		f.mu.Unlock()
 		return TaskID{}, err
 	}
-
-	// Collect all TaskIDs that match the given treePath
-	var matchingIDs []TaskID
-	for _, file := range files {
-		if file.IsDir() || !strings.HasPrefix(file.Name(), TaskIDPrefix) || !strings.HasSuffix(file.Name(), ".md") {
+	var ids []TaskID
+	for _, rel := range idx.paths() {
+		if dir := filepath.Dir(rel); dir != "." && dir != archivedDir && dir != trashDir {
 			continue
 		}
-		id, err := parseTaskIDfromFileName(file.Name())
-		if err != nil {
+		id, err := parseTaskID(idx.Entries[rel].ID)
+		if err != nil || id.IsZero() {
 			continue // Skip files with invalid IDs
 		}
+		ids = append(ids, id)
+	}
+	f.mu.Unlock()
+
+	// Collect all TaskIDs that match the given treePath
+	var matchingIDs []TaskID
+	for _, id := range ids {
 		// Check if the ID matches the desired tree path
 		if len(id.seg) < len(treePath) {
 			continue
@@ -105,28 +184,40 @@ func (f *FileTaskStore) getNextTaskID(treePath ...int) (TaskID, error) {
 	return TaskID{seg: nextSeg}, nil
 }
 
-// FindTaskFileByID searches the tasks directory for a task file matching the given ID.
-// The ID can be in the format "T123" or just "123".
-func (f *FileTaskStore) findTaskFileByID(id TaskID) (string, error) {
-	var foundPath string
-	files, err := afero.ReadDir(f.fs, f.tasksDir)
-	if err != nil {
-		return "", err
+// findTaskFileByID looks up the task file matching the given ID in the index
+// and returns its cached content. The directory is only walked again when the
+// ID is unknown or its file changed since it was indexed.
+// Active tasks take precedence over archived tasks with the same ID.
+func (f *FileTaskStore) findTaskFileByID(id TaskID) (indexedTask, error) {
+	found, err := f.findTaskFileIn(id, ".")
+	if errors.Is(err, ErrNotFound) {
+		return f.findTaskFileIn(id, archivedDir)
 	}
+	return found, err
+}
 
-	for _, file := range files {
-		if file.IsDir() {
-			continue
+// findTaskFileIn is like findTaskFileByID for tasks stored in dir, relative to the tasks directory.
+func (f *FileTaskStore) findTaskFileIn(id TaskID, dir string) (indexedTask, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.idx == nil {
+		f.idx = loadTaskIndex(f.fs, f.tasksDir)
+		f.idx.workflow = f.workflow
+	}
+	rel, ok := f.idx.find(id, dir)
+	if !ok || !f.idx.fresh(f.fs, f.tasksDir, rel) {
+		idx, err := f.index()
+		if err != nil {
+			return indexedTask{}, err
 		}
-		if strings.HasPrefix(file.Name(), id.Name()) && strings.HasSuffix(file.Name(), ".md") {
-			foundPath = filepath.Join(f.tasksDir, file.Name())
-			break
+		if rel, ok = idx.find(id, dir); !ok {
+			return indexedTask{}, fmt.Errorf("task with ID '%s' %w", id, ErrNotFound)
 		}
 	}
-
-	if foundPath != "" {
-		return foundPath, nil
+	path := filepath.Join(f.tasksDir, rel)
+	e := f.idx.Entries[rel]
+	if e.Err != "" {
+		return indexedTask{}, fmt.Errorf("parse task %s: %s", path, e.Err)
 	}
-
-	return "", fmt.Errorf("task with ID '%s' not found", id)
+	return indexedTask{Path: path, Task: e.task(f.idx.workflow), Archived: dir == archivedDir}, nil
 }
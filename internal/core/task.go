@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,7 +14,11 @@ import (
 	"go.yaml.in/yaml/v4"
 )
 
-var ErrInvalid = errors.New("invalid value")
+var (
+	ErrInvalid  = errors.New("invalid value")
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+)
 
 // NewTask creates a new Task with default values.
 func NewTask() Task {
@@ -39,9 +44,15 @@ type Task struct {
 	Labels       MaybeStringArray `json:"labels,omitempty"       yaml:"labels,omitempty"`
 	Dependencies MaybeStringArray `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 	Priority     Priority         `json:"priority,omitempty"     yaml:"priority,omitempty"`
+	Start        Date             `json:"start,omitempty"        yaml:"start,omitempty"`
+	Due          Date             `json:"due,omitempty"          yaml:"due,omitempty"`
+	Estimate     float64          `json:"estimate,omitempty"     yaml:"estimate,omitempty"` // in points
+	Spent        Duration         `json:"spent,omitempty"        yaml:"spent,omitempty"`
 	CreatedAt    time.Time        `json:"created_at"             yaml:"created_at"`
 	UpdatedAt    time.Time        `json:"updated_at,omitzero"    yaml:"updated_at,omitempty"`
 	History      []HistoryEntry   `json:"history,omitempty"      yaml:"history,omitempty"`
+	// Fields holds the custom fields, declared or not, see FieldDef.
+	Fields map[string]any `json:"fields,omitempty" yaml:"-"`
 
 	// --- Markdown Body Fields ---
 
@@ -49,6 +60,46 @@ type Task struct {
 	AcceptanceCriteria  []AcceptanceCriterion `json:"acceptance_criteria,omitempty"`
 	ImplementationPlan  string                `json:"implementation_plan"`
 	ImplementationNotes string                `json:"implementation_notes"`
+	// ExtraSections holds the markdown of the body that is not one of the
+	// sections above, such as a section added by hand, kept as written.
+	ExtraSections []ExtraSection `json:"extra_sections,omitempty"`
+
+	// --- Computed Fields ---
+
+	// Progress is set when the task is read, if it has subtasks or acceptance criteria.
+	Progress *Progress `json:"progress,omitempty" yaml:"-"`
+	// Effort is set when the task is read, if it or its subtasks have an estimate or time spent.
+	Effort *Effort `json:"effort,omitempty" yaml:"-"`
+
+	// keyOrder is the order of the keys of the front matter as read, kept
+	// when the task is written back, see marshalFrontMatter.
+	keyOrder []string
+	// dateFields are the custom fields written as YAML timestamps: the fields
+	// holding timestamps when read and the declared date fields, see fieldsNode.
+	dateFields []string
+}
+
+// ExtraSection is markdown of the body of a task kept as written, placed after
+// the section managed by backlog it followed.
+type ExtraSection struct {
+	// After is the header of the managed section the text follows, such as
+	// "## Description", empty for the text at the start of the body. The text
+	// of the acceptance criteria section before its first criterion follows
+	// the marker starting the list.
+	After string `json:"after,omitempty"`
+	// Before is the marker starting the list of acceptance criteria for the
+	// text of that section written before it.
+	Before string `json:"before,omitempty"`
+	Text   string `json:"text"`
+}
+
+// Version returns the time the task was last modified: its update time, or its
+// creation time if it was never updated. It is used to detect concurrent edits.
+func (t Task) Version() time.Time {
+	if t.UpdatedAt.IsZero() {
+		return t.CreatedAt
+	}
+	return t.UpdatedAt
 }
 
 var slugRegex = regexp.MustCompile(`[^a-zA-Z0-9_.\(\)\[\]]+`)
@@ -81,31 +132,62 @@ func (t *Task) Bytes() []byte {
 		Labels:       t.Labels,
 		Parent:       t.Parent.String(),
 		Priority:     t.Priority.String(),
+		Start:        t.Start,
+		Due:          t.Due,
+		Estimate:     t.Estimate,
+		Spent:        t.Spent,
 		Dependencies: t.Dependencies,
 		CreatedAt:    t.CreatedAt,
 		UpdatedAt:    t.UpdatedAt,
 		History:      t.History,
 	}
 
-	frontMatterBytes, err := yaml.Marshal(frontmatter)
+	frontMatterBytes, err := marshalFrontMatter(frontmatter, t.Fields, t.dateFields, t.keyOrder)
 	if err != nil {
 		logging.Error("failed to marshal frontmatter", "task_id", t.ID, "error", err)
 		return nil
 	}
 
 	var body bytes.Buffer
+	// extra writes the extra sections following the given managed section
+	extra := func(after string) {
+		for _, e := range t.ExtraSections {
+			if e.After == after && e.Before == "" {
+				body.WriteString(fmt.Sprintf("%s\n\n", e.Text))
+			}
+		}
+	}
+	extra("")
 	body.WriteString(fmt.Sprintf("%s\n\n%s\n\n", descHeader, t.Description))
-	body.WriteString(fmt.Sprintf("%s\n%s\n\n", acHeader, acStartComment))
+	extra(descHeader)
+	body.WriteString(acHeader + "\n")
+	for _, e := range t.ExtraSections {
+		if e.Before == acStartComment {
+			body.WriteString(fmt.Sprintf("\n%s\n\n", e.Text))
+		}
+	}
+	body.WriteString(acStartComment + "\n\n")
+	extra(acStartComment)
 	for _, ac := range t.AcceptanceCriteria {
 		checked := " "
 		if ac.Checked {
 			checked = "x"
 		}
 		body.WriteString(fmt.Sprintf("- [%s] #%d %s\n", checked, ac.Index, ac.Text))
+		if ac.Extra != "" {
+			body.WriteString(ac.Extra + "\n")
+		}
 	}
 	body.WriteString(fmt.Sprintf("\n%s\n\n", acEndComment))
+	extra(acHeader)
 	body.WriteString(fmt.Sprintf("%s\n\n%s\n\n", planHeader, t.ImplementationPlan))
+	extra(planHeader)
 	body.WriteString(fmt.Sprintf("%s\n\n%s\n", notesHeader, t.ImplementationNotes))
+	for _, e := range t.ExtraSections {
+		if e.After == notesHeader && e.Before == "" {
+			body.WriteString(fmt.Sprintf("\n%s\n", e.Text))
+		}
+	}
 
 	// Combine front matter and body
 	var fullContent bytes.Buffer
@@ -117,18 +199,90 @@ func (t *Task) Bytes() []byte {
 	return fullContent.Bytes()
 }
 
+// marshalFrontMatter encodes the front matter with the custom fields after
+// the built-in fields but before the history, which grows with every change.
+// The keys are then written in the order they were read, if known, new keys
+// following the key they follow in the default order.
+func marshalFrontMatter(frontmatter *Frontmatter, fields map[string]any, dateFields, order []string) ([]byte, error) {
+	if len(fields) == 0 && len(order) == 0 {
+		return yaml.Marshal(frontmatter)
+	}
+	var node yaml.Node
+	if err := node.Encode(frontmatter); err != nil {
+		return nil, err
+	}
+	extra, err := fieldsNode(fields, dateFields)
+	if err != nil {
+		return nil, err
+	}
+	at := len(node.Content)
+	if len(frontmatter.History) > 0 {
+		at -= 2 // history key and value
+	}
+	node.Content = slices.Insert(node.Content, at, extra.Content...)
+	if len(order) > 0 {
+		node.Content = orderKeys(node.Content, order, fields)
+	}
+	return yaml.Marshal(&node)
+}
+
+// orderKeys reorders the key and value nodes of a mapping: the keys found in
+// order come first, in that order, and the others are inserted after the key
+// preceding them in the mapping, a built-in key only following built-in keys.
+func orderKeys(content []*yaml.Node, order []string, fields map[string]any) []*yaml.Node {
+	values := make(map[string]*yaml.Node, len(content)/2)
+	var keys []string
+	for i := 0; i+1 < len(content); i += 2 {
+		keys = append(keys, content[i].Value)
+		values[content[i].Value] = content[i+1]
+	}
+	var sorted []string
+	for _, k := range order {
+		if _, ok := values[k]; ok && !slices.Contains(sorted, k) {
+			sorted = append(sorted, k)
+		}
+	}
+	custom := func(k string) bool {
+		_, ok := fields[k]
+		return ok
+	}
+	for i, k := range keys {
+		if slices.Contains(sorted, k) {
+			continue
+		}
+		at := 0
+		for j := i - 1; j >= 0; j-- {
+			if custom(keys[j]) && !custom(k) {
+				continue
+			}
+			at = slices.Index(sorted, keys[j]) + 1
+			break
+		}
+		sorted = slices.Insert(sorted, at, k)
+	}
+	ordered := make([]*yaml.Node, 0, len(content))
+	for _, k := range sorted {
+		i := slices.Index(keys, k)
+		ordered = append(ordered, content[2*i], values[k])
+	}
+	return ordered
+}
+
 // AcceptanceCriterion represents a single item in the acceptance criteria list.
 type AcceptanceCriterion struct {
 	Text    string `json:"text"`
 	Checked bool   `json:"checked"`
 	Index   int    `json:"index"`
+	// Extra is the markdown written by hand after the criterion in the list,
+	// kept as written.
+	Extra string `json:"extra,omitempty"`
 }
 
 // HistoryEntry represents a single entry in the task's history.
 type HistoryEntry struct {
 	Timestamp time.Time      `json:"timestamp"          yaml:"timestamp"`
 	Change    string         `json:"change"             yaml:"change"`
-	Type      string         `json:"type,omitempty"     yaml:"type,omitempty"`     // Type of change: "field_update", "id_change", "conflict_resolution", etc.
+	Type      string         `json:"type,omitempty"     yaml:"type,omitempty"`     // Type of change: "field_update", "status_change", "id_change", "conflict_resolution", etc.
 	Metadata  map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"` // Additional metadata about the change
 }
 
@@ -142,8 +296,22 @@ func RecordChange(task *Task, change string) {
 	task.History = append(task.History, entry)
 }
 
+// RecordStatusChange adds a history entry for a status transition
+func RecordStatusChange(task *Task, oldStatus, newStatus Status) {
+	entry := HistoryEntry{
+		Timestamp: time.Now().UTC(),
+		Change:    fmt.Sprintf("Status changed from %q to %q", oldStatus, newStatus),
+		Type:      "status_change",
+		Metadata: map[string]any{
+			"old_status": string(oldStatus),
+			"new_status": string(newStatus),
+		},
+	}
+	task.History = append(task.History, entry)
+}
+
 // RecordIDChange adds a specialized history entry for ID changes during conflict resolution
-func RecordIDChange(task Task, oldID, newID TaskID, reason string, metadata map[string]any) {
+func RecordIDChange(task *Task, oldID, newID TaskID, reason string, metadata map[string]any) {
 	if metadata == nil {
 		metadata = make(map[string]any)
 	}
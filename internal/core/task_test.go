@@ -106,3 +106,149 @@ func TestParseTaskWithDependencies(t *testing.T) {
 		is.True(slices.Contains(taskCFromFile.Dependencies.ToSlice(), taskB.ID.Name()))
 	})
 }
+
+func TestHandEditedRoundTrip(t *testing.T) {
+	is := is.New(t)
+	content := `---
+id: "01"
+title: Hand edited
+owner_team: payments
+status: todo
+priority: medium
+created_at: 2025-09-01T10:00:00Z
+---
+Intro before the description.
+
+## Description
+
+Some description.
+
+` + "```md\n## Implementation Plan\nnot a header\n```" + `
+
+## Acceptance Criteria
+<!-- AC:BEGIN -->
+
+- [ ] #1 First
+
+<!-- AC:END -->
+
+See [the spec](https://example.com/spec).
+
+## Notes from review
+
+- keep this
+
+## Implementation Plan
+
+1. plan
+
+## Implementation Notes
+
+notes
+
+## Appendix
+
+Trailing section.
+`
+	task, err := parseTask([]byte(content))
+	is.NoErr(err)
+	is.Equal(task.Description, "Some description.\n\n```md\n## Implementation Plan\nnot a header\n```") // headers in code blocks are text
+	is.Equal(task.ImplementationPlan, "1. plan")
+	is.Equal(len(task.AcceptanceCriteria), 1)
+	is.Equal(task.ExtraSections, []ExtraSection{
+		{Text: "Intro before the description."},
+		{After: acHeader, Text: "See [the spec](https://example.com/spec)."},
+		{After: acHeader, Text: "## Notes from review\n\n- keep this"},
+		{After: notesHeader, Text: "## Appendix\n\nTrailing section."},
+	})
+	is.Equal(string(task.Bytes()), content) // written back unchanged
+
+	// through the store and its index
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	is.NoErr(afero.WriteFile(fs, ".backlog/T01-hand_edited.md", []byte(content), 0o644))
+	_, err = store.List(ListTasksParams{}) // index the file
+	is.NoErr(err)
+	store = NewFileTaskStore(fs, ".backlog") // read from the index on disk
+	got, err := store.Get("1")
+	is.NoErr(err)
+	is.NoErr(store.Update(&got, EditTaskParams{ID: "1", CheckAC: []int{1}}))
+	b, err := afero.ReadFile(fs, ".backlog/T01-hand_edited.md")
+	is.NoErr(err)
+	edited := string(b)
+	is.True(strings.Contains(edited, "- [x] #1 First"))
+	is.True(strings.HasPrefix(edited, "---\nid: \"01\"\ntitle: Hand edited\nowner_team: payments\nstatus: todo\n")) // key order kept
+	for _, part := range []string{"Intro before the description.\n\n## Description", "<!-- AC:END -->\n\nSee [the spec]", "- keep this\n\n## Implementation Plan", "notes\n\n## Appendix\n\nTrailing section.\n"} {
+		is.True(strings.Contains(edited, part)) // extra sections kept in place
+	}
+}
+
+func TestHandEditedAcceptanceCriteria(t *testing.T) {
+	is := is.New(t)
+	content := `---
+id: "01"
+title: Hand edited
+status: todo
+priority: medium
+created_at: 2025-09-01T10:00:00Z
+---
+## Description
+
+Some description.
+
+## Acceptance Criteria
+
+Please verify carefully.
+
+<!-- AC:BEGIN -->
+
+Checked by QA:
+
+- [ ] #1 First
+  with details
+- [ ] #2 Second
+
+Only on staging.
+
+- [ ] #3 Third
+> after the last one
+
+<!-- AC:END -->
+
+## Implementation Plan
+
+1. plan
+
+## Implementation Notes
+
+notes
+`
+	task, err := parseTask([]byte(content))
+	is.NoErr(err)
+	is.Equal(task.AcceptanceCriteria, []AcceptanceCriterion{
+		{Text: "First", Index: 1, Extra: "  with details"},
+		{Text: "Second", Index: 2, Extra: "\nOnly on staging.\n"},
+		{Text: "Third", Index: 3, Extra: "> after the last one"},
+	})
+	is.Equal(task.ExtraSections, []ExtraSection{
+		{Before: acStartComment, Text: "Please verify carefully."},
+		{After: acStartComment, Text: "Checked by QA:"},
+	})
+	is.Equal(string(task.Bytes()), content) // written back unchanged
+
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+	is.NoErr(afero.WriteFile(fs, ".backlog/T01-hand_edited.md", []byte(content), 0o644))
+	got, err := store.Get("1")
+	is.NoErr(err)
+	is.NoErr(store.Update(&got, EditTaskParams{ID: "1", NewPriority: ptr("low")}))
+	b, err := afero.ReadFile(fs, ".backlog/T01-hand_edited.md")
+	is.NoErr(err)
+	is.True(strings.Contains(string(b), "## Acceptance Criteria\n\nPlease verify carefully.\n\n<!-- AC:BEGIN -->\n\nChecked by QA:\n\n- [ ] #1 First\n  with details\n"))
+
+	// the text after a removed criterion is kept after the one before it
+	is.NoErr(store.Update(&got, EditTaskParams{ID: "1", RemoveAC: []int{2}}))
+	b, err = afero.ReadFile(fs, ".backlog/T01-hand_edited.md")
+	is.NoErr(err)
+	is.True(strings.Contains(string(b), "- [ ] #1 First\n  with details\n\nOnly on staging.\n\n- [ ] #2 Third\n> after the last one\n\n<!-- AC:END -->"))
+}
@@ -0,0 +1,223 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// History entry types of the timers.
+const (
+	historyTimerStarted = "timer_started"
+	historyTimerStopped = "timer_stopped"
+)
+
+// StartTimerParams holds the parameters for starting a timer on a task.
+type StartTimerParams struct {
+	ID       string `json:"id"                 jsonschema:"Required. The ID of the task."`
+	Assignee string `json:"assignee,omitempty" jsonschema:"Who works on the task, one of its assignees. Required unless the task has a single assignee. A task without assignee is assigned to them. Each assignee runs one timer at a time."`
+}
+
+// StopTimerParams holds the parameters for stopping a running timer.
+type StopTimerParams struct {
+	ID       string `json:"id,omitempty"       jsonschema:"The ID of the task, needed only if several timers are running."`
+	Assignee string `json:"assignee,omitempty" jsonschema:"Whose timer to stop, needed only if several timers are running."`
+}
+
+// Timer is the time spent working on a task by an assignee, between the
+// timer_started and timer_stopped entries of its history.
+type Timer struct {
+	Task     Task      `json:"task"`
+	Assignee string    `json:"assignee,omitempty"`
+	Started  time.Time `json:"started"`
+	Stopped  time.Time `json:"stopped,omitzero"`
+	// Elapsed is the time added to the time spent on the task when the timer stops.
+	Elapsed Duration `json:"elapsed"`
+}
+
+// RunningTimers returns the start times of the timers running on the task,
+// by assignee, read from its history.
+func (t Task) RunningTimers() map[string]time.Time {
+	running := make(map[string]time.Time)
+	for _, h := range t.History {
+		assignee, _ := h.Metadata["assignee"].(string)
+		switch h.Type {
+		case historyTimerStarted:
+			running[assignee] = h.Timestamp
+		case historyTimerStopped:
+			delete(running, assignee)
+		}
+	}
+	return running
+}
+
+// StartTimer starts a timer on a task for an assignee and sets the task
+// in-progress. The assignee must be one of the task, and may only be left out
+// when the task has a single one. A task without assignee is assigned to the
+// one starting the timer. An assignee cannot run two timers at once.
+func (f *FileTaskStore) StartTimer(params StartTimerParams) (Timer, error) {
+	id, err := parseTaskID(params.ID)
+	if err != nil {
+		return Timer{}, fmt.Errorf("invalid task ID '%s': %w", params.ID, err)
+	}
+	unlock, err := f.lock()
+	if err != nil {
+		return Timer{}, err
+	}
+	defer unlock()
+	found, err := f.findTaskFileIn(id, ".")
+	if err != nil {
+		return Timer{}, fmt.Errorf("find task %s: %w", id.Name(), err)
+	}
+	task := found.Task
+	assignee := strings.TrimSpace(params.Assignee)
+	switch {
+	case assignee == "" && len(task.Assigned) == 1:
+		assignee = task.Assigned[0]
+	case assignee == "" && len(task.Assigned) == 0:
+		return Timer{}, fmt.Errorf("task %s has no assignee, give who works on it: %w", id.Name(), ErrInvalid)
+	case assignee == "":
+		return Timer{}, fmt.Errorf("task %s has %d assignees (%s), give who works on it: %w",
+			id.Name(), len(task.Assigned), strings.Join(task.Assigned, ", "), ErrInvalid)
+	case len(task.Assigned) == 0:
+		RecordChange(&task, fmt.Sprintf("Assigned changed from %q to %q", task.Assigned, []string{assignee}))
+		task.Assigned = []string{assignee}
+	case !slices.Contains(task.Assigned, assignee):
+		return Timer{}, fmt.Errorf("%s is not assigned to task %s (%s): %w",
+			assignee, id.Name(), strings.Join(task.Assigned, ", "), ErrInvalid)
+	}
+
+	tasks, err := f.loadAll(".")
+	if err != nil {
+		return Timer{}, fmt.Errorf("loading tasks: %v", err)
+	}
+	for _, t := range tasks {
+		if started, ok := t.RunningTimers()[assignee]; ok {
+			return Timer{}, fmt.Errorf("the timer of %s is already running on task %s since %s, stop it first: %w",
+				assignee, t.ID.Name(), started.Local().Format(time.DateTime), ErrConflict)
+		}
+	}
+
+	if f.workflow.Known(StatusInProgress) && task.Status != StatusInProgress {
+		if err := f.workflow.CheckTransition(task.Status, StatusInProgress); err != nil {
+			return Timer{}, err
+		}
+		RecordStatusChange(&task, task.Status, StatusInProgress)
+		task.Status = StatusInProgress
+	}
+	now := time.Now().UTC()
+	task.History = append(task.History, HistoryEntry{
+		Timestamp: now,
+		Change:    "Timer started by " + assignee,
+		Type:      historyTimerStarted,
+		Metadata:  map[string]any{"assignee": assignee},
+	})
+	task.UpdatedAt = now
+	if err := f.writeFile(found.Path, task); err != nil {
+		return Timer{}, fmt.Errorf("could not write task file: %w", err)
+	}
+	return Timer{Task: task, Assignee: assignee, Started: now}, nil
+}
+
+// StopTimer stops a running timer and adds the elapsed time, rounded to the
+// minute, to the time spent on the task. Without ID nor assignee, the only
+// running timer is stopped.
+func (f *FileTaskStore) StopTimer(params StopTimerParams) (Timer, error) {
+	var id TaskID
+	if params.ID != "" {
+		var err error
+		if id, err = parseTaskID(params.ID); err != nil {
+			return Timer{}, fmt.Errorf("invalid task ID '%s': %w", params.ID, err)
+		}
+	}
+	assignee := strings.TrimSpace(params.Assignee)
+	unlock, err := f.lock()
+	if err != nil {
+		return Timer{}, err
+	}
+	defer unlock()
+
+	tasks, err := f.loadAll(".")
+	if err != nil {
+		return Timer{}, fmt.Errorf("loading tasks: %v", err)
+	}
+	var matches []Timer
+	for _, t := range tasks {
+		if params.ID != "" && !t.ID.Equals(id) {
+			continue
+		}
+		for a, started := range t.RunningTimers() {
+			if assignee == "" || a == assignee {
+				matches = append(matches, Timer{Task: t, Assignee: a, Started: started})
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Timer{}, fmt.Errorf("no timer running%s: %w", timerFilter(params.ID, assignee), ErrNotFound)
+	case 1:
+	default:
+		var running []string
+		for _, m := range matches {
+			running = append(running, strings.TrimSpace(m.Task.ID.Name()+" "+m.Assignee))
+		}
+		slices.Sort(running)
+		return Timer{}, fmt.Errorf("%d timers running%s (%s), give the task ID or the assignee: %w",
+			len(matches), timerFilter(params.ID, assignee), strings.Join(running, ", "), ErrInvalid)
+	}
+
+	timer := matches[0]
+	found, err := f.findTaskFileIn(timer.Task.ID, ".")
+	if err != nil {
+		return Timer{}, fmt.Errorf("find task %s: %w", timer.Task.ID.Name(), err)
+	}
+	task := found.Task
+	timer.Stopped = time.Now().UTC()
+	timer.Elapsed = Duration(timer.Stopped.Sub(timer.Started).Round(time.Minute))
+	change := fmt.Sprintf("Timer stopped after %s", timer.Elapsed)
+	if timer.Assignee != "" {
+		change = fmt.Sprintf("Timer of %s stopped after %s", timer.Assignee, timer.Elapsed)
+	}
+	task.History = append(task.History, HistoryEntry{
+		Timestamp: timer.Stopped,
+		Change:    change,
+		Type:      historyTimerStopped,
+		Metadata: map[string]any{
+			"assignee": timer.Assignee,
+			"started":  timer.Started.Format(time.RFC3339),
+			"duration": timer.Elapsed.String(),
+		},
+	})
+	task.Spent += timer.Elapsed
+	task.UpdatedAt = timer.Stopped
+	if err := f.writeFile(found.Path, task); err != nil {
+		return Timer{}, fmt.Errorf("could not write task file: %w", err)
+	}
+	timer.Task = task
+	return timer, nil
+}
+
+// checkNoRunningTimer returns an error if a timer runs on the task, since
+// timers are only stopped on active tasks. action is what would be done to it.
+func checkNoRunningTimer(task Task, action string) error {
+	running := task.RunningTimers()
+	if len(running) == 0 {
+		return nil
+	}
+	assignees := slices.Sorted(maps.Keys(running))
+	return fmt.Errorf("cannot %s task %s, the timer of %s is running, stop it first: %w",
+		action, task.ID.Name(), strings.Join(assignees, ", "), ErrConflict)
+}
+
+func timerFilter(id, assignee string) string {
+	var s string
+	if id != "" {
+		s += " on task " + id
+	}
+	if assignee != "" {
+		s += " for " + assignee
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestTimers(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+
+	_, err := store.Create(CreateTaskParams{Title: "Billing", Assigned: []string{"alice"}})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Docs"})
+	is.NoErr(err)
+	_, err = store.Create(CreateTaskParams{Title: "Release", Assigned: []string{"alice", "bob"}})
+	is.NoErr(err)
+
+	timer, err := store.StartTimer(StartTimerParams{ID: "T01"})
+	is.NoErr(err)
+	is.Equal(timer.Assignee, "alice") // the only assignee
+	is.Equal(timer.Task.Status, StatusInProgress)
+	last := timer.Task.History[len(timer.Task.History)-1]
+	is.Equal(last.Type, "timer_started")
+	is.Equal(last.Change, "Timer started by alice")
+
+	_, err = store.StartTimer(StartTimerParams{ID: "T03", Assignee: "alice"})
+	is.True(errors.Is(err, ErrConflict)) // one timer at a time
+	_, err = store.StartTimer(StartTimerParams{ID: "T03"})
+	is.True(errors.Is(err, ErrInvalid)) // two assignees
+	_, err = store.StartTimer(StartTimerParams{ID: "T03", Assignee: "carol"})
+	is.True(errors.Is(err, ErrInvalid)) // not assigned
+	_, err = store.StartTimer(StartTimerParams{ID: "T03", Assignee: "bob"})
+	is.NoErr(err)
+	_, err = store.StartTimer(StartTimerParams{ID: "T02"})
+	is.True(errors.Is(err, ErrInvalid)) // no assignee
+	docs, err := store.StartTimer(StartTimerParams{ID: "T02", Assignee: "carol"})
+	is.NoErr(err)
+	is.Equal(docs.Task.Assigned, MaybeStringArray{"carol"}) // unassigned tasks are assigned to who starts the timer
+	docsTask, err := store.Get("T02")
+	is.NoErr(err)
+	is.Equal(docsTask.Assigned, docs.Task.Assigned)
+	_, err = store.StartTimer(StartTimerParams{ID: "T02", Assignee: "dave"})
+	is.True(errors.Is(err, ErrInvalid)) // not assigned any more
+	_, err = store.StartTimer(StartTimerParams{ID: "T09"})
+	is.True(errors.Is(err, ErrNotFound))
+
+	// the timer of alice started 90 minutes ago
+	found, err := store.findTaskFileIn(timer.Task.ID, ".")
+	is.NoErr(err)
+	found.Task.History[len(found.Task.History)-1].Timestamp = time.Now().UTC().Add(-90 * time.Minute)
+	is.NoErr(store.writeFile(found.Path, found.Task))
+
+	_, err = store.StopTimer(StopTimerParams{})
+	is.True(errors.Is(err, ErrInvalid)) // three timers running
+	stopped, err := store.StopTimer(StopTimerParams{Assignee: "alice"})
+	is.NoErr(err)
+	is.Equal(stopped.Task.Title, "Billing")
+	is.Equal(stopped.Elapsed, Duration(90*time.Minute))
+	is.Equal(stopped.Task.Spent, Duration(90*time.Minute))
+	last = stopped.Task.History[len(stopped.Task.History)-1]
+	is.Equal(last.Type, "timer_stopped")
+	is.Equal(last.Change, "Timer of alice stopped after 1h30m")
+	is.Equal(last.Metadata["duration"], "1h30m")
+	_, err = store.StopTimer(StopTimerParams{Assignee: "alice"})
+	is.True(errors.Is(err, ErrNotFound))
+
+	got, err := store.Get("T01")
+	is.NoErr(err)
+	is.Equal(len(got.RunningTimers()), 0)
+	got, err = store.Get("T03")
+	is.NoErr(err)
+	is.Equal(len(got.RunningTimers()), 1)
+
+	_, err = store.StopTimer(StopTimerParams{ID: "T03"})
+	is.NoErr(err)
+	stopped, err = store.StopTimer(StopTimerParams{}) // the only one left
+	is.NoErr(err)
+	is.Equal(stopped.Task.Title, "Docs")
+	is.Equal(stopped.Assignee, "carol")
+
+	// alice can start again
+	_, err = store.StartTimer(StartTimerParams{ID: "T03", Assignee: "alice"})
+	is.NoErr(err)
+
+	// the time spent would be lost with the task
+	_, err = store.Archive(mustParseTaskID("T03"))
+	is.True(errors.Is(err, ErrConflict))
+	_, err = store.Delete(DeleteTaskParams{ID: "T03"})
+	is.True(errors.Is(err, ErrConflict))
+	_, err = store.StopTimer(StopTimerParams{ID: "T03"})
+	is.NoErr(err)
+	_, err = store.Archive(mustParseTaskID("T03"))
+	is.NoErr(err)
+}
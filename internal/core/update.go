@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 )
 
@@ -14,6 +16,10 @@ type EditTaskParams struct {
 	NewStatus       *string  `json:"new_status,omitempty"       jsonschema:"A new status (e.g., 'in-progress', 'done')."`
 	NewPriority     *string  `json:"new_priority,omitempty"     jsonschema:"A new priority."`
 	NewParent       *string  `json:"new_parent,omitempty"       jsonschema:"A new parent task ID."`
+	NewStart        *string  `json:"new_start,omitempty"        jsonschema:"A new start date: YYYY-MM-DD, today, tomorrow, a weekday like friday or a number of days like +3d. Empty to remove it."`
+	NewDue          *string  `json:"new_due,omitempty"          jsonschema:"A new due date: YYYY-MM-DD, today, tomorrow, a weekday like friday or a number of days like +3d. Empty to remove it."`
+	NewEstimate     *float64 `json:"new_estimate,omitempty"     jsonschema:"A new estimate in points, 0 to remove it."`
+	NewSpent        *string  `json:"new_spent,omitempty"        jsonschema:"A new total time spent, such as 1h30m, to correct it, empty to remove it. Use task_log_time to add time spent."`
 	AddAssigned     []string `json:"add_assigned,omitempty"     jsonschema:"A new list of assigned."`
 	RemoveAssigned  []string `json:"remove_assigned,omitempty"  jsonschema:"A list of assigned to remove."`
 	AddLabels       []string `json:"add_labels,omitempty"       jsonschema:"Add new list of labels."`
@@ -25,10 +31,91 @@ type EditTaskParams struct {
 	CheckAC         []int    `json:"check_ac,omitempty"         jsonschema:"A list of 1-based indices of AC to check."`
 	UncheckAC       []int    `json:"uncheck_ac,omitempty"       jsonschema:"A list of 1-based indices of AC to uncheck."`
 	RemoveAC        []int    `json:"remove_ac,omitempty"        jsonschema:"A list of 1-based indices of AC to remove."`
+	// SetFields sets the values of custom fields, see FieldDef. A null or
+	// empty value removes the field.
+	SetFields map[string]any `json:"set_fields,omitempty" jsonschema:"Values of custom fields of the repository to set, null or empty to remove a field."`
+	// ExpectedUpdatedAt makes the edit fail with an EditConflictError if the
+	// task was modified since this version was read, see Task.Version.
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty" jsonschema:"The updated_at (or created_at if absent) of the task as last read. The edit is rejected if the task changed since."`
+}
+
+// EditConflictError is returned by Update when the task was modified after
+// the version expected by the caller. It wraps ErrConflict.
+type EditConflictError struct {
+	ID       TaskID
+	Expected time.Time
+	Actual   time.Time
+}
+
+func (e *EditConflictError) Error() string {
+	return fmt.Sprintf("task %s was modified at %s, expected version %s",
+		e.ID.Name(), e.Actual.Format(time.RFC3339Nano), e.Expected.Format(time.RFC3339Nano))
+}
+
+func (e *EditConflictError) Unwrap() error { return ErrConflict }
+
+// EditResult lists the files changed by an edit besides the file of the task.
+type EditResult struct {
+	// OldPath is the former file of the task, if its title or ID changed.
+	OldPath string
+	// Moved are the subtasks renumbered along with the task when its parent changed.
+	Moved []MovedTask
+	// References are the other tasks whose dependencies on the renumbered tasks were rewritten.
+	References []Task
+	// Parents are the parent tasks marked done because the task completed them.
+	Parents []Task
+}
+
+// Paths returns the files changed by the edit besides the file of the task,
+// given the path of a task file.
+func (r EditResult) Paths(path func(Task) string) []string {
+	paths := []string{r.OldPath}
+	for _, moved := range r.Moved {
+		paths = append(paths, moved.Path, moved.OldPath)
+	}
+	for _, t := range slices.Concat(r.References, r.Parents) {
+		paths = append(paths, path(t))
+	}
+	return paths
 }
 
 // Update updates an existing task based on the provided parameters.
 func (f *FileTaskStore) Update(task *Task, params EditTaskParams) error {
+	_, err := f.Edit(task, params)
+	return err
+}
+
+// Edit is Update returning the other files changed by the edit, such as the
+// subtasks and dependents of a task whose parent changed.
+func (f *FileTaskStore) Edit(task *Task, params EditTaskParams) (EditResult, error) {
+	unlock, err := f.lock()
+	if err != nil {
+		return EditResult{}, err
+	}
+	defer unlock()
+	return f.update(task, params)
+}
+
+// update is Edit for callers already holding the lock.
+// When the parent changes, the subtasks follow the task to its new ID and
+// the references to the renumbered tasks are rewritten.
+func (f *FileTaskStore) update(task *Task, params EditTaskParams) (result EditResult, err error) {
+	oldID := task.ID
+	current, err := f.findTaskFileIn(task.ID, ".")
+	if errors.Is(err, ErrNotFound) {
+		if _, err := f.findTaskFileIn(task.ID, archivedDir); err == nil {
+			return result, fmt.Errorf("task %s is archived, unarchive it first: %w", task.ID, ErrInvalid)
+		}
+	}
+	if params.ExpectedUpdatedAt != nil {
+		if err != nil {
+			return result, fmt.Errorf("get task %s: %w", task.ID, err)
+		}
+		if v := current.Task.Version(); !v.Equal(*params.ExpectedUpdatedAt) {
+			return result, &EditConflictError{ID: task.ID, Expected: *params.ExpectedUpdatedAt, Actual: v}
+		}
+	}
+
 	var oldFilePath string
 
 	// Update fields based on params
@@ -44,12 +131,15 @@ func (f *FileTaskStore) Update(task *Task, params EditTaskParams) error {
 	}
 
 	if params.NewStatus != nil {
-		newStatus, err := ParseStatus(*params.NewStatus)
+		newStatus, err := f.workflow.Parse(*params.NewStatus)
 		if err != nil {
-			return fmt.Errorf("invalid status %q: %w", *params.NewStatus, err)
+			return result, fmt.Errorf("invalid status %q: %w", *params.NewStatus, err)
+		}
+		if err := f.workflow.CheckTransition(task.Status, newStatus); err != nil {
+			return result, err
 		}
 		if task.Status != newStatus {
-			RecordChange(task, fmt.Sprintf("Status changed from %q to %q", task.Status, newStatus))
+			RecordStatusChange(task, task.Status, newStatus)
 			task.Status = newStatus
 		}
 	}
@@ -76,7 +166,7 @@ func (f *FileTaskStore) Update(task *Task, params EditTaskParams) error {
 	if params.NewPriority != nil {
 		newPriority, err := ParsePriority(*params.NewPriority)
 		if err != nil {
-			return fmt.Errorf("invalid priority %q: %w", *params.NewPriority, err)
+			return result, fmt.Errorf("invalid priority %q: %w", *params.NewPriority, err)
 		}
 		if task.Priority != newPriority {
 			RecordChange(task, fmt.Sprintf("Priority changed from %q to %q", task.Priority, newPriority))
@@ -84,22 +174,84 @@ func (f *FileTaskStore) Update(task *Task, params EditTaskParams) error {
 		}
 	}
 
+	now := time.Now()
+	for _, date := range []struct {
+		name  string
+		value *string
+		field *Date
+	}{
+		{"Start date", params.NewStart, &task.Start},
+		{"Due date", params.NewDue, &task.Due},
+	} {
+		if date.value == nil {
+			continue
+		}
+		d, err := ParseDate(*date.value, now)
+		if err != nil {
+			return result, fmt.Errorf("invalid %s: %w", strings.ToLower(date.name), err)
+		}
+		if d != *date.field {
+			RecordChange(task, fmt.Sprintf("%s changed from %q to %q", date.name, *date.field, d))
+			*date.field = d
+		}
+	}
+	if params.NewStart != nil || params.NewDue != nil {
+		if err := checkDates(*task); err != nil {
+			return result, err
+		}
+	}
+
+	if params.NewEstimate != nil {
+		estimate, err := parseEstimate(*params.NewEstimate)
+		if err != nil {
+			return result, err
+		}
+		if task.Estimate != estimate {
+			RecordChange(task, fmt.Sprintf("Estimate changed from %s to %s", formatPoints(task.Estimate), formatPoints(estimate)))
+			task.Estimate = estimate
+		}
+	}
+	if params.NewSpent != nil {
+		var spent Duration // an empty value removes the time spent
+		if *params.NewSpent != "" {
+			if spent, err = ParseDuration(*params.NewSpent); err != nil {
+				return result, err
+			}
+		}
+		if spent < 0 {
+			return result, fmt.Errorf("time spent %s cannot be negative: %w", spent, ErrInvalid)
+		}
+		if task.Spent != spent {
+			RecordChange(task, fmt.Sprintf("Time spent changed from %s to %s", task.Spent, spent))
+			task.Spent = spent
+		}
+	}
+
 	if params.NewParent != nil {
 		newParent, err := parseTaskID(*params.NewParent)
 		if err != nil {
-			return fmt.Errorf("invalid new parent task ID '%s': %w", *params.NewParent, err)
+			return result, fmt.Errorf("invalid new parent task ID '%s': %w", *params.NewParent, err)
 		}
 		if !task.Parent.Equals(newParent) {
+			if newParent.Equals(task.ID) || isDescendant(newParent, task.ID) {
+				return result, fmt.Errorf("task %s cannot become a subtask of %s: %w", task.ID, newParent, ErrInvalid)
+			}
+			if !newParent.IsZero() {
+				if _, err := f.findTaskFileIn(newParent, "."); err != nil {
+					return result, fmt.Errorf("new parent task ID '%s' does not exist: %w", *params.NewParent, err)
+				}
+			}
 			if oldFilePath == "" {
 				oldFilePath = f.Path(*task) // Save old file path before ID changes
 			}
-			RecordChange(task, fmt.Sprintf("Parent changed from %q to %q", task.Parent.String(), newParent.String()))
+			RecordParentChange(task, task.Parent, newParent, "edit")
 			task.Parent = newParent
 			// Recalculate task ID to be a subtask of the new parent
 			nextID, err := f.getNextTaskID(newParent.seg...)
 			if err != nil {
-				return fmt.Errorf("could not get next task ID for new parent: %w", err)
+				return result, fmt.Errorf("could not get next task ID for new parent: %w", err)
 			}
+			RecordIDChange(task, task.ID, nextID, "parent change", nil)
 			task.ID = nextID
 		}
 	}
@@ -120,33 +272,68 @@ func (f *FileTaskStore) Update(task *Task, params EditTaskParams) error {
 		for _, depIDStr := range params.NewDependencies {
 			depID, err := parseTaskID(depIDStr)
 			if err != nil {
-				return fmt.Errorf("invalid dependency task ID '%s': %w", depIDStr, err)
+				return result, fmt.Errorf("invalid dependency task ID '%s': %w", depIDStr, err)
 			}
 			_, err = f.Get(depID.String())
 			if err != nil {
-				return fmt.Errorf("dependency task ID '%s' does not exist: %w", depIDStr, err)
+				return result, fmt.Errorf("dependency task ID '%s' does not exist: %w", depIDStr, err)
 			}
 			deps = append(deps, depID.Name())
 		}
+		if err := f.validateDependencies(task.ID, deps); err != nil {
+			return result, err
+		}
 
 		RecordChange(task, fmt.Sprintf("Dependencies changed from %q to %q", task.Dependencies, deps))
 		task.Dependencies = deps
 	}
 
+	if err := f.setFields(task, params.SetFields, true); err != nil {
+		return result, err
+	}
+
 	// Handle acceptance criteria changes
 	handleACChanges(task, params)
 
-	task.UpdatedAt = time.Now().UTC()
+	task.UpdatedAt = now.UTC()
+	task.Progress = nil
+	// An explicit status is kept. The subtasks of a moved task only follow it after the write.
+	if f.autoDoneParents && params.NewStatus == nil && oldID.Equals(task.ID) {
+		active, err := f.loadAll(".")
+		if err != nil {
+			return result, fmt.Errorf("loading tasks: %v", err)
+		}
+		autoDone(task, active, f.workflow)
+	}
 
 	if err := f.write(*task); err != nil {
-		return fmt.Errorf("could not write updated task file: %w", err)
+		return result, fmt.Errorf("could not write updated task file: %w", err)
 	}
 	if oldFilePath != "" {
 		if err := f.fs.Remove(oldFilePath); err != nil {
-			return fmt.Errorf("could not remove old file: %w", err)
+			return result, fmt.Errorf("could not remove old file: %w", err)
 		}
+		result.OldPath = oldFilePath
 	}
-	return nil
+	if !task.ID.Equals(oldID) {
+		var rewritten []Task
+		if result.Moved, rewritten, err = f.moveSubtree(oldID, task.ID); err != nil {
+			return result, fmt.Errorf("could not move subtasks of %s: %w", oldID, err)
+		}
+		for _, t := range rewritten {
+			if t.ID.Equals(task.ID) {
+				*task = t // depends on one of its subtasks
+			} else {
+				result.References = append(result.References, t)
+			}
+		}
+	}
+	if f.autoDoneParents && task.Status != StatusArchived { // archived subtasks leave their parent
+		if result.Parents, err = f.completeParents(task.ID); err != nil {
+			return result, fmt.Errorf("could not complete parent tasks of %s: %w", task.ID, err)
+		}
+	}
+	return result, nil
 }
 
 func batchRemoveAdd(orig []string, toRemove []string, toAdd []string) []string {
@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 	"sort"
+	"strings"
 )
 
 // handleACChanges processes acceptance criteria changes for a task
@@ -33,12 +34,27 @@ func removeACs(task *Task, indicesToRemove []int) {
 				newACs = append(newACs, criterion)
 			} else {
 				RecordChange(task, fmt.Sprintf("Removed acceptance criterion #%d: %q", criterion.Index, criterion.Text))
+				keepACExtra(task, newACs, criterion.Extra)
 			}
 		}
 		task.AcceptanceCriteria = newACs
 	}
 }
 
+// keepACExtra keeps the text written by hand after a removed criterion, moving
+// it after the criterion before it, or before the list.
+func keepACExtra(task *Task, before []AcceptanceCriterion, extra string) {
+	switch {
+	case extra == "":
+	case len(before) > 0 && before[len(before)-1].Extra == "":
+		before[len(before)-1].Extra = extra
+	case len(before) > 0:
+		before[len(before)-1].Extra = strings.TrimRight(before[len(before)-1].Extra, "\n") + "\n" + extra
+	default:
+		task.ExtraSections = append(task.ExtraSections, ExtraSection{After: acStartComment, Text: strings.Trim(extra, "\n")})
+	}
+}
+
 // checkACs marks acceptance criteria as checked
 func checkACs(task *Task, indicesToCheck []int) {
 	for _, indexToCheck := range indicesToCheck {
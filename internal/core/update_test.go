@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"errors"
 	"slices"
 	"strings"
 	"testing"
@@ -73,6 +74,8 @@ func TestUpdateTaskFields(t *testing.T) {
 
 	// Create a parent task for testing parent update
 	parentTask, _ := store.Create(core.CreateTaskParams{Title: "Parent Task"})
+	// and one to depend on, a task cannot depend on its parent
+	depTask, _ := store.Create(core.CreateTaskParams{Title: "Dependency Task"})
 
 	t.Run("update various fields", func(t *testing.T) {
 		task, _ := store.Create(core.CreateTaskParams{
@@ -96,7 +99,7 @@ func TestUpdateTaskFields(t *testing.T) {
 		newNotes := "These are the implementation notes."
 		newPlan := "This is the implementation plan."
 		newTitle := "Updated Task Title"
-		newDeps := []string{"T01", "T02"}
+		newDeps := []string{depTask.ID.Name()}
 		newAssigned := []string{"alice", "bob"}
 
 		params := core.EditTaskParams{
@@ -183,3 +186,40 @@ func TestUpdateTaskFields(t *testing.T) {
 		is.True(err != nil) // Expecting an error
 	})
 }
+
+func TestEditTask_ExpectedUpdatedAt(t *testing.T) {
+	is := is.New(t)
+	store := core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	created, err := store.Create(core.CreateTaskParams{Title: "Shared Task"})
+	is.NoErr(err)
+
+	// Agent A reads the task, agent B edits it first.
+	readByA, err := store.Get("T01")
+	is.NoErr(err)
+	is.Equal(readByA.Version(), created.CreatedAt) // never updated
+	readByB, err := store.Get("T01")
+	is.NoErr(err)
+	is.NoErr(store.Update(&readByB, core.EditTaskParams{
+		NewStatus:         ptr("in-progress"),
+		ExpectedUpdatedAt: ptr(readByB.Version()),
+	}))
+
+	err = store.Update(&readByA, core.EditTaskParams{
+		NewStatus:         ptr("done"),
+		ExpectedUpdatedAt: ptr(readByA.Version()),
+	})
+	is.True(errors.Is(err, core.ErrConflict))
+	var conflict *core.EditConflictError
+	is.True(errors.As(err, &conflict))
+	is.True(conflict.Actual.Equal(readByB.UpdatedAt))
+
+	task, err := store.Get("T01")
+	is.NoErr(err)
+	is.Equal(task.Status, core.StatusInProgress) // B's edit is kept
+
+	// Retrying with the version as read from disk succeeds.
+	is.NoErr(store.Update(&task, core.EditTaskParams{
+		NewStatus:         ptr("done"),
+		ExpectedUpdatedAt: ptr(task.Version()),
+	}))
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v4"
+)
+
+// ViewsFile is the file of the tasks directory defining the saved views.
+const ViewsFile = "views.yaml"
+
+// ViewColumns are the columns a view can display, in their default order.
+var ViewColumns = []string{"id", "status", "title", "dependencies", "labels", "priority", "due", "assigned", "progress"}
+
+// View is a named list of tasks saved under the views key of ViewsFile:
+// filters, sorting and pagination with the keys of ListTasksParams, plus the
+// columns to display.
+//
+//	views:
+//	  triage:
+//	    description: Bugs and important work to triage
+//	    where: "status:todo and (label:bug or priority>=high)"
+//	    sort: [priority]
+//	    columns: [id, title, priority, labels]
+//	    limit: 20
+type View struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Columns     []string `json:"columns,omitempty"`
+	ListTasksParams
+}
+
+var viewNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Views returns the saved views, sorted by name. There are none if the views file does not exist.
+func (f *FileTaskStore) Views() ([]View, error) {
+	path := filepath.Join(f.tasksDir, ViewsFile)
+	data, err := afero.ReadFile(f.fs, path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []View{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var raw struct {
+		Views map[string]map[string]any `yaml:"views"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %v: %w", path, err, ErrInvalid)
+	}
+	views := make([]View, 0, len(raw.Views))
+	for name, fields := range raw.Views {
+		view, err := parseView(name, fields)
+		if err != nil {
+			return nil, fmt.Errorf("%s: view %q: %w", path, name, err)
+		}
+		views = append(views, view)
+	}
+	slices.SortFunc(views, func(a, b View) int { return strings.Compare(a.Name, b.Name) })
+	return views, nil
+}
+
+// View returns the saved view with the given name.
+func (f *FileTaskStore) View(name string) (View, error) {
+	views, err := f.Views()
+	if err != nil {
+		return View{}, err
+	}
+	i := slices.IndexFunc(views, func(v View) bool { return v.Name == name })
+	if i < 0 {
+		return View{}, fmt.Errorf("view %q %w", name, ErrNotFound)
+	}
+	return views[i], nil
+}
+
+// parseView decodes the fields of a view through JSON so that the keys of the
+// views file are the ones of ListTasksParams in the MCP tools.
+func parseView(name string, fields map[string]any) (View, error) {
+	if !viewNameRegex.MatchString(name) {
+		return View{}, fmt.Errorf("name must only contain letters, digits, '-' and '_': %w", ErrInvalid)
+	}
+	for _, key := range []string{"name", "view"} {
+		if _, ok := fields[key]; ok {
+			return View{}, fmt.Errorf("unknown key %q: %w", key, ErrInvalid)
+		}
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return View{}, fmt.Errorf("%v: %w", err, ErrInvalid)
+	}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.DisallowUnknownFields()
+	var view View
+	if err := dec.Decode(&view); err != nil {
+		return View{}, fmt.Errorf("%v: %w", err, ErrInvalid)
+	}
+	view.Name = name
+	for _, c := range view.Columns {
+		if !slices.Contains(ViewColumns, c) {
+			return View{}, fmt.Errorf("unknown column %q (want %s): %w", c, strings.Join(ViewColumns, ", "), ErrInvalid)
+		}
+	}
+	return view, nil
+}
+
+// applyView fills the parameters left empty with the ones of the view named
+// by params.View, so that explicit parameters refine the view.
+func (f *FileTaskStore) applyView(params *ListTasksParams) error {
+	if params.View == "" {
+		return nil
+	}
+	view, err := f.View(params.View)
+	if err != nil {
+		return err
+	}
+	dst := reflect.ValueOf(params).Elem()
+	src := reflect.ValueOf(view.ListTasksParams)
+	for i := range dst.NumField() {
+		if dst.Field(i).IsZero() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+	params.View = ""
+	return nil
+}
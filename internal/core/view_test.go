@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestViews(t *testing.T) {
+	is := is.New(t)
+	fs := afero.NewMemMapFs()
+	store := NewFileTaskStore(fs, ".backlog")
+
+	views, err := store.Views()
+	is.NoErr(err)
+	is.Equal(len(views), 0) // no views file
+
+	for _, p := range []CreateTaskParams{
+		{Title: "Login bug", Labels: []string{"bug"}, Priority: "high"},
+		{Title: "Docs", Priority: "low"},
+		{Title: "Crash", Labels: []string{"bug"}, Priority: "critical"},
+		{Title: "Typo", Labels: []string{"bug"}, Priority: "low"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	is.NoErr(afero.WriteFile(fs, ".backlog/views.yaml", []byte(`views:
+  triage:
+    description: Bugs to triage
+    where: "label:bug"
+    sort: [priority]
+    columns: [id, title, priority]
+    limit: 2
+  all:
+    archived: include
+`), 0o644))
+
+	views, err = store.Views()
+	is.NoErr(err)
+	is.Equal(len(views), 2)
+	is.Equal(views[0].Name, "all")
+	is.Equal(views[0].Archived, ArchivedInclude)
+	is.Equal(views[1].Name, "triage")
+	is.Equal(views[1].Description, "Bugs to triage")
+	is.Equal(views[1].Columns, []string{"id", "title", "priority"})
+
+	result, err := store.List(ListTasksParams{View: "triage"})
+	is.NoErr(err)
+	is.Equal(len(result.Tasks), 2)
+	is.Equal(result.Tasks[0].Title, "Crash")
+	is.Equal(result.Tasks[1].Title, "Login bug")
+	is.Equal(result.Pagination.TotalResults, 3)
+
+	// explicit parameters refine the view
+	result, err = store.List(ListTasksParams{View: "triage", Priority: "low"})
+	is.NoErr(err)
+	is.Equal(len(result.Tasks), 1)
+	is.Equal(result.Tasks[0].Title, "Typo")
+
+	_, err = store.List(ListTasksParams{View: "missing"})
+	is.True(errors.Is(err, ErrNotFound))
+
+	for _, content := range []string{
+		"views:\n  bad:\n    colour: red\n",
+		"views:\n  bad:\n    columns: [id, colour]\n",
+		"views:\n  bad:\n    view: triage\n",
+		"views:\n  bad name:\n    limit: 1\n",
+		"views: [",
+	} {
+		is.NoErr(afero.WriteFile(fs, ".backlog/views.yaml", []byte(content), 0o644))
+		_, err := store.Views()
+		is.True(errors.Is(err, ErrInvalid)) // invalid views file
+	}
+}
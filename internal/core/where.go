@@ -0,0 +1,546 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// taskPredicate reports whether a task matches a condition.
+type taskPredicate func(Task) bool
+
+// parseWhere parses a query such as
+//
+//	status:todo and (label:bug or priority>=high) and updated<7d
+//
+// into a predicate. A query is made of terms combined with "and", "or", "not"
+// and parentheses, terms next to each other being combined with "and".
+// A term is either a field, an operator and a value, such as title:refactor,
+// or a bare word matching the text of the tasks like the list query.
+// Values with spaces are quoted: title:"big refactor".
+//
+// The operators are ":" (contains for text fields, equals otherwise), "=",
+// "!=", "<", "<=", ">" and ">=". Dates are compared to a day (2025-09-01),
+// a time (RFC 3339), or an age relative to now such as 7d, 2w or 12h:
+// updated<7d matches the tasks updated less than 7 days ago. The start and
+// due dates are compared to days given like to ParseDate instead: due<+7d.
+func parseWhere(query string, env whereEnv) (taskPredicate, error) {
+	tokens, err := lexWhere(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &whereParser{tokens: tokens, env: env}
+	pred, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("where: unexpected %q at position %d: %w", tok.text, tok.pos, ErrInvalid)
+	}
+	return pred, nil
+}
+
+type whereTokenKind int
+
+const (
+	whereWord   whereTokenKind = iota // bare word
+	whereTerm                         // field, operator and value
+	whereLParen                       // (
+	whereRParen                       // )
+)
+
+type whereToken struct {
+	kind  whereTokenKind
+	text  string // as written, for errors and keywords
+	field string
+	op    string
+	value string
+	pos   int
+}
+
+// whereOperators are the term operators, longest first.
+var whereOperators = []string{"!=", "<=", ">=", ":", "=", "<", ">"}
+
+func lexWhere(query string) ([]whereToken, error) {
+	var tokens []whereToken
+	r := []rune(query)
+	i := 0
+	// readValue reads a quoted string or a run of characters up to a space or a parenthesis.
+	readValue := func() (string, error) {
+		if i < len(r) && r[i] == '"' {
+			start := i
+			i++
+			var b strings.Builder
+			for ; i < len(r) && r[i] != '"'; i++ {
+				if r[i] == '\\' && i+1 < len(r) {
+					i++
+				}
+				b.WriteRune(r[i])
+			}
+			if i == len(r) {
+				return "", fmt.Errorf("where: unterminated quote at position %d: %w", start, ErrInvalid)
+			}
+			i++
+			return b.String(), nil
+		}
+		start := i
+		for i < len(r) && !unicode.IsSpace(r[i]) && r[i] != '(' && r[i] != ')' {
+			i++
+		}
+		return string(r[start:i]), nil
+	}
+
+	for i < len(r) {
+		switch c := r[i]; {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, whereToken{kind: whereLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, whereToken{kind: whereRParen, text: ")", pos: i})
+			i++
+		default:
+			start := i
+			for i < len(r) && (unicode.IsLetter(r[i]) || r[i] == '_') {
+				i++
+			}
+			tok := whereToken{kind: whereWord, pos: start}
+			if i > start {
+				for _, op := range whereOperators {
+					if strings.HasPrefix(string(r[i:]), op) {
+						tok.kind = whereTerm
+						tok.field = strings.ToLower(string(r[start:i]))
+						tok.op = op
+						i += len([]rune(op))
+						break
+					}
+				}
+			}
+			if tok.kind == whereWord {
+				i = start
+			}
+			value, err := readValue()
+			if err != nil {
+				return nil, err
+			}
+			tok.value = value
+			tok.text = string(r[start:i])
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens, nil
+}
+
+// whereEnv holds what the predicates depend on besides the tasks.
+type whereEnv struct {
+	now      time.Time  // ages are relative to it
+	workflow Workflow   // statuses
+	fields   []FieldDef // custom fields
+}
+
+type whereParser struct {
+	tokens []whereToken
+	pos    int
+	env    whereEnv
+}
+
+func (p *whereParser) peek() (whereToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return whereToken{}, false
+}
+
+// keyword reports whether the next token is the given keyword, and consumes it.
+func (p *whereParser) keyword(kw string) bool {
+	tok, ok := p.peek()
+	if ok && tok.kind == whereWord && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *whereParser) parseOr() (taskPredicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(t Task) bool { return l(t) || right(t) }
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseAnd() (taskPredicate, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		explicit := p.keyword("and")
+		tok, ok := p.peek()
+		if !explicit && (!ok || tok.kind == whereRParen || (tok.kind == whereWord && strings.EqualFold(tok.text, "or"))) {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(t Task) bool { return l(t) && right(t) }
+	}
+}
+
+func (p *whereParser) parseNot() (taskPredicate, error) {
+	if p.keyword("not") {
+		pred, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(t Task) bool { return !pred(t) }, nil
+	}
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("where: unexpected end of query: %w", ErrInvalid)
+	}
+	p.pos++
+	switch tok.kind {
+	case whereLParen:
+		pred, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.kind != whereRParen {
+			return nil, fmt.Errorf("where: missing closing parenthesis for the one at position %d: %w", tok.pos, ErrInvalid)
+		}
+		p.pos++
+		return pred, nil
+	case whereRParen:
+		return nil, fmt.Errorf("where: unexpected %q at position %d: %w", tok.text, tok.pos, ErrInvalid)
+	case whereTerm:
+		field, ok := whereFields[tok.field]
+		if def, custom := lookupField(p.env.fields, tok.field); !ok && custom {
+			field, ok = customField(def), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("where: unknown field %q at position %d: %w", tok.field, tok.pos, ErrInvalid)
+		}
+		pred, err := field(tok.op, tok.value, p.env)
+		if err != nil {
+			return nil, fmt.Errorf("where: %s: %w", tok.text, err)
+		}
+		return pred, nil
+	default:
+		if strings.EqualFold(tok.text, "and") || strings.EqualFold(tok.text, "or") {
+			return nil, fmt.Errorf("where: unexpected %q at position %d: %w", tok.text, tok.pos, ErrInvalid)
+		}
+		text := strings.ToLower(tok.value)
+		return func(t Task) bool { return taskContains(t, text) }, nil
+	}
+}
+
+// whereField builds the predicate of a term on a field from its operator and value.
+type whereField func(op, value string, env whereEnv) (taskPredicate, error)
+
+// whereFields are the fields usable in a where query, by name.
+var whereFields = map[string]whereField{
+	"id":          idField(func(t Task) TaskID { return t.ID }),
+	"parent":      idField(func(t Task) TaskID { return t.Parent }),
+	"dep":         dependencyField,
+	"dependency":  dependencyField,
+	"status":      statusField,
+	"priority":    priorityField,
+	"label":       listField(func(t Task) []string { return t.Labels }),
+	"labels":      listField(func(t Task) []string { return t.Labels }),
+	"assigned":    listField(func(t Task) []string { return t.Assigned }),
+	"assignee":    listField(func(t Task) []string { return t.Assigned }),
+	"title":       textField(func(t Task) string { return t.Title }),
+	"description": textField(func(t Task) string { return t.Description }),
+	"desc":        textField(func(t Task) string { return t.Description }),
+	"plan":        textField(func(t Task) string { return t.ImplementationPlan }),
+	"notes":       textField(func(t Task) string { return t.ImplementationNotes }),
+	"ac":          acField,
+	"text":        textSearchField,
+	"start":       dateField(func(t Task) Date { return t.Start }),
+	"due":         dateField(func(t Task) Date { return t.Due }),
+	"created":     timeField(func(t Task) time.Time { return t.CreatedAt }),
+	"updated":     timeField(Task.Version),
+}
+
+func invalidOp(op string) error {
+	return fmt.Errorf("operator %q is not supported for this field: %w", op, ErrInvalid)
+}
+
+// compareOp applies a comparison operator to the result of a comparison.
+func compareOp(op string, c int) bool {
+	switch op {
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	default: // ":" and "="
+		return c == 0
+	}
+}
+
+func equalityOp(op string) bool {
+	return op == ":" || op == "=" || op == "!="
+}
+
+// idField matches a task ID, "root" or an empty value meaning no ID.
+func idField(field func(Task) TaskID) whereField {
+	return func(op, value string, _ whereEnv) (taskPredicate, error) {
+		if !equalityOp(op) {
+			return nil, invalidOp(op)
+		}
+		var id TaskID
+		if !strings.EqualFold(value, "root") {
+			var err error
+			if id, err = parseTaskID(value); err != nil {
+				return nil, err
+			}
+		}
+		return func(t Task) bool { return field(t).Equals(id) != (op == "!=") }, nil
+	}
+}
+
+func dependencyField(op, value string, _ whereEnv) (taskPredicate, error) {
+	if !equalityOp(op) {
+		return nil, invalidOp(op)
+	}
+	id, err := parseTaskID(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(t Task) bool {
+		found := slices.Contains(dependencyIDs(t.Dependencies), id.String())
+		return found != (op == "!=")
+	}, nil
+}
+
+func statusField(op, value string, env whereEnv) (taskPredicate, error) {
+	if !equalityOp(op) {
+		return nil, invalidOp(op)
+	}
+	status, err := env.workflow.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(t Task) bool { return compareOp(op, strings.Compare(string(t.Status), string(status))) }, nil
+}
+
+func priorityField(op, value string, _ whereEnv) (taskPredicate, error) {
+	priority, err := ParsePriority(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(t Task) bool { return compareOp(op, int(t.Priority)-int(priority)) }, nil
+}
+
+// listField matches a value of a list, case insensitively.
+func listField(values func(Task) []string) whereField {
+	return func(op, value string, _ whereEnv) (taskPredicate, error) {
+		if !equalityOp(op) {
+			return nil, invalidOp(op)
+		}
+		return func(t Task) bool {
+			found := slices.ContainsFunc(values(t), func(v string) bool { return strings.EqualFold(strings.TrimSpace(v), value) })
+			return found != (op == "!=")
+		}, nil
+	}
+}
+
+// textField matches text containing the value with ":", or equal to it with
+// "=" and "!=", case insensitively.
+func textField(text func(Task) string) whereField {
+	return func(op, value string, _ whereEnv) (taskPredicate, error) {
+		if !equalityOp(op) {
+			return nil, invalidOp(op)
+		}
+		value = strings.ToLower(value)
+		if op == ":" {
+			return func(t Task) bool { return strings.Contains(strings.ToLower(text(t)), value) }, nil
+		}
+		return func(t Task) bool { return (strings.ToLower(text(t)) == value) != (op == "!=") }, nil
+	}
+}
+
+func acField(op, value string, _ whereEnv) (taskPredicate, error) {
+	if op != ":" {
+		return nil, invalidOp(op)
+	}
+	value = strings.ToLower(value)
+	return func(t Task) bool {
+		return slices.ContainsFunc(t.AcceptanceCriteria, func(ac AcceptanceCriterion) bool {
+			return strings.Contains(strings.ToLower(ac.Text), value)
+		})
+	}, nil
+}
+
+func textSearchField(op, value string, _ whereEnv) (taskPredicate, error) {
+	if op != ":" {
+		return nil, invalidOp(op)
+	}
+	value = strings.ToLower(value)
+	return func(t Task) bool { return taskContains(t, value) }, nil
+}
+
+// timeField compares a time to a day, a time or, for a relative value such
+// as 7d, compares the age of the time.
+func timeField(field func(Task) time.Time) whereField {
+	return func(op, value string, env whereEnv) (taskPredicate, error) {
+		if age, ok, err := parseAge(value); ok {
+			if err != nil {
+				return nil, err
+			}
+			if op == ":" {
+				op = "<=" // within
+			}
+			return func(t Task) bool {
+				ft := field(t)
+				return !ft.IsZero() && compareOp(op, cmp.Compare(env.now.Sub(ft), age))
+			}, nil
+		}
+		if day, err := time.Parse(time.DateOnly, value); err == nil {
+			return func(t Task) bool {
+				ft := field(t)
+				return !ft.IsZero() && compareOp(op, ft.UTC().Truncate(24*time.Hour).Compare(day))
+			}, nil
+		}
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("date %q (want YYYY-MM-DD, RFC 3339 or an age like 7d): %w", value, ErrInvalid)
+		}
+		return func(t Task) bool {
+			ft := field(t)
+			return !ft.IsZero() && compareOp(op, ft.Compare(at))
+		}, nil
+	}
+}
+
+// dateField compares a date to a day given like to ParseDate: due<+7d
+// matches the tasks due within a week. An empty value matches the tasks
+// without a date.
+func dateField(field func(Task) Date) whereField {
+	return func(op, value string, env whereEnv) (taskPredicate, error) {
+		want, err := ParseDate(value, env.now)
+		if err != nil {
+			return nil, err
+		}
+		return func(t Task) bool {
+			got := field(t)
+			if want == "" || got == "" {
+				return (got == want) != (op == "!=")
+			}
+			return compareOp(op, strings.Compare(string(got), string(want)))
+		}, nil
+	}
+}
+
+// customField matches a custom field according to its type: strings like
+// text fields, enums like priorities in the order of their values, ints as
+// numbers, dates like created and lists like labels. For ints and enums, an
+// empty value matches the tasks without a value.
+func customField(def FieldDef) whereField {
+	value := func(t Task) any { return t.Fields[def.Name] }
+	switch def.Type {
+	case FieldList:
+		return listField(func(t Task) []string { return fieldList(value(t)) })
+	case FieldDate:
+		return timeField(func(t Task) time.Time {
+			at, _ := time.Parse(time.DateOnly, formatFieldValue(value(t)))
+			return at
+		})
+	case FieldString:
+		return textField(func(t Task) string { return formatFieldValue(value(t)) })
+	}
+	return func(op, s string, _ whereEnv) (taskPredicate, error) {
+		want, err := def.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return func(t Task) bool {
+			got, ok := t.Fields[def.Name]
+			if want == nil { // an empty value matches the tasks without one
+				return ok == (op == "!=")
+			}
+			if !ok {
+				return op == "!="
+			}
+			return compareOp(op, compareFieldValues(def, got, want))
+		}, nil
+	}
+}
+
+// parseTimeBound parses a point in time: an RFC 3339 time, a date, meaning
+// the start of that day in UTC, or an age relative to now such as 7d.
+func parseTimeBound(value string, now time.Time) (time.Time, error) {
+	if age, ok, err := parseAge(value); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(-age), nil
+	}
+	if day, err := time.Parse(time.DateOnly, value); err == nil {
+		return day, nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q (want YYYY-MM-DD, RFC 3339 or an age like 7d): %w", value, ErrInvalid)
+	}
+	return at, nil
+}
+
+// parseAge parses a relative age made of a number and a unit: m (minutes),
+// h (hours), d (days) or w (weeks). It reports whether the value looks like an age.
+func parseAge(value string) (time.Duration, bool, error) {
+	if len(value) < 2 {
+		return 0, false, nil
+	}
+	units := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil {
+		return 0, false, nil
+	}
+	if n < 0 {
+		return 0, true, fmt.Errorf("negative age %q: %w", value, ErrInvalid)
+	}
+	return time.Duration(n) * unit, true, nil
+}
+
+// taskContains reports whether the text fields, labels or assigned names of
+// the task contain the lowercase text.
+func taskContains(task Task, text string) bool {
+	contains := func(s string) bool { return strings.Contains(strings.ToLower(s), text) }
+	if contains(task.Title) ||
+		contains(task.Description) ||
+		contains(task.ImplementationPlan) ||
+		contains(task.ImplementationNotes) ||
+		contains(task.Priority.String()) {
+		return true
+	}
+	return slices.ContainsFunc(task.AcceptanceCriteria, func(ac AcceptanceCriterion) bool { return contains(ac.Text) }) ||
+		slices.ContainsFunc(task.Labels, contains) ||
+		slices.ContainsFunc(task.Assigned, contains)
+}
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/spf13/afero"
+)
+
+func TestParseWhere(t *testing.T) {
+	now := time.Date(2025, 9, 15, 12, 0, 0, 0, time.UTC)
+	tasks := []Task{
+		{
+			ID: mustParseTaskID("01"), Title: "Refactor the parser", Status: StatusTodo, Priority: PriorityHigh,
+			Labels: MaybeStringArray{"tech-debt"}, CreatedAt: now.AddDate(0, -1, 0), UpdatedAt: now.AddDate(0, 0, -2),
+			Due: "2025-09-20",
+		},
+		{
+			ID: mustParseTaskID("02"), Title: "Login fails", Status: StatusTodo, Priority: PriorityLow,
+			Labels: MaybeStringArray{"bug"}, Assigned: MaybeStringArray{"alice"}, Dependencies: MaybeStringArray{"T01"},
+			CreatedAt: now.AddDate(0, 0, -20), Description: "Users see a big error", Start: "2025-09-01", Due: "2025-09-10",
+		},
+		{
+			ID: mustParseTaskID("02.01"), Parent: mustParseTaskID("02"), Title: "Write a test", Status: StatusDone, Priority: PriorityMedium,
+			CreatedAt: now.AddDate(0, 0, -1), AcceptanceCriteria: []AcceptanceCriterion{{Text: "Covers the error", Index: 1}},
+		},
+	}
+
+	for _, tc := range []struct {
+		where string
+		want  []string
+	}{
+		{"status:todo", []string{"01", "02"}},
+		{"not status:done", []string{"01", "02"}},
+		{"label:bug or priority>=high", []string{"01", "02"}},
+		{"status:todo and (label:BUG or priority>=high) and updated<7d", []string{"01"}},
+		{"status:todo (label:bug or priority>medium)", []string{"01", "02"}},
+		{"priority<=medium", []string{"02", "02.01"}},
+		{"title:refactor", []string{"01"}},
+		{`title="write a test"`, []string{"02.01"}},
+		{`description:"big error"`, []string{"02"}},
+		{"ac:covers", []string{"02.01"}},
+		{"error", []string{"02", "02.01"}},
+		{"created>2025-09-01", []string{"02.01"}},
+		{"created:2025-09-14", []string{"02.01"}},
+		{"created<2w", []string{"02.01"}},
+		{"updated>=1w", []string{"02"}},
+		{"assigned:alice", []string{"02"}},
+		{"dep:T01", []string{"02"}},
+		{"parent:T02", []string{"02.01"}},
+		{"parent:root and id!=T01", []string{"02"}},
+		{"due<+7d", []string{"01", "02"}},
+		{"due<today", []string{"02"}},
+		{"due>=friday", []string{"01"}}, // 2025-09-19
+		{"due=", []string{"02.01"}},
+		{"start:2025-09-01", []string{"02"}},
+	} {
+		pred, err := parseWhere(tc.where, whereEnv{now: now, workflow: DefaultWorkflow()})
+		if err != nil {
+			t.Fatalf("%q: %v", tc.where, err)
+		}
+		var got []string
+		for _, task := range tasks {
+			if pred(task) {
+				got = append(got, task.ID.String())
+			}
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%q: got %v, want %v", tc.where, got, tc.want)
+		}
+	}
+
+	for _, where := range []string{
+		"status:todo and",
+		"(status:todo",
+		"status:todo)",
+		"color:red",
+		"status:maybe",
+		"priority:urgent",
+		"title>refactor",
+		"created<yesterday",
+		"due<7d",
+		`title:"unterminated`,
+		"or status:todo",
+	} {
+		_, err := parseWhere(where, whereEnv{now: now, workflow: DefaultWorkflow()})
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("%q: got error %v, want ErrInvalid", where, err)
+		}
+	}
+}
+
+func TestListWhere(t *testing.T) {
+	is := is.New(t)
+	store := NewFileTaskStore(afero.NewMemMapFs(), ".backlog")
+	for _, p := range []CreateTaskParams{
+		{Title: "Fix login", Labels: []string{"bug"}},
+		{Title: "Refactor", Priority: "high"},
+		{Title: "Docs", Priority: "low"},
+	} {
+		_, err := store.Create(p)
+		is.NoErr(err)
+	}
+	result, err := store.List(ListTasksParams{Where: "label:bug or priority>=high", Sort: []string{"id"}})
+	is.NoErr(err)
+	is.Equal(len(result.Tasks), 2)
+	is.Equal(result.Tasks[0].Title, "Fix login")
+	is.Equal(result.Tasks[1].Title, "Refactor")
+
+	_, err = store.List(ListTasksParams{Where: "label:"})
+	is.NoErr(err) // empty value
+	_, err = store.List(ListTasksParams{Where: "(label:bug"})
+	is.True(errors.Is(err, ErrInvalid))
+}
+
+func TestFilterTasksByTime(t *testing.T) {
+	is := is.New(t)
+	now := time.Now().UTC()
+	tasks := []Task{
+		{ID: mustParseTaskID("01"), CreatedAt: time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC), UpdatedAt: now.AddDate(0, 0, -1)},
+		{ID: mustParseTaskID("02"), CreatedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: mustParseTaskID("03"), CreatedAt: now.AddDate(0, 0, -3)},
+	}
+	ids := func(params ListTasksParams) []string {
+		filtered, err := filterTasks(tasks, params, DefaultWorkflow(), nil)
+		is.NoErr(err)
+		var ids []string
+		for _, task := range filtered {
+			ids = append(ids, task.ID.String())
+		}
+		return ids
+	}
+
+	is.Equal(ids(ListTasksParams{CreatedAfter: "2025-09-01"}), []string{"02", "03"})
+	is.Equal(ids(ListTasksParams{CreatedBefore: "2025-09-01"}), []string{"01"})
+	is.Equal(ids(ListTasksParams{CreatedAfter: "2025-08-01", CreatedBefore: "2025-09-02"}), []string{"01", "02"})
+	is.Equal(ids(ListTasksParams{CreatedAfter: "2025-08-20T12:00:00Z"}), []string{"02", "03"})
+	is.Equal(ids(ListTasksParams{CreatedAfter: "1w"}), []string{"03"})
+	is.Equal(ids(ListTasksParams{UpdatedSince: "7d"}), []string{"01", "03"}) // created recently counts as updated
+	is.Equal(ids(ListTasksParams{UpdatedSince: "2d"}), []string{"01"})
+
+	_, err := filterTasks(tasks, ListTasksParams{UpdatedSince: "last week"}, DefaultWorkflow(), nil)
+	is.True(errors.Is(err, ErrInvalid))
+}
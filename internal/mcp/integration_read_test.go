@@ -35,7 +35,7 @@ func TestMCP_Integration_Read_HTTP(t *testing.T) {
 	{
 		res, err := sess.ListTools(t.Context(), &mcp.ListToolsParams{})
 		is.NoErr(err)
-		is.Equal(len(res.Tools), 6) // task_create, task_batch_create, task_list, task_view, task_edit, task_archive
+		is.Equal(len(res.Tools), 15) // task_create, task_batch_create, task_list, task_view, task_edit, task_archive, task_unarchive, task_delete, task_move, task_next, task_critical_path, task_search, task_log_time, task_start_timer, task_stop_timer
 	}
 	{
 		res, err := sess.ListPrompts(t.Context(), &mcp.ListPromptsParams{})
@@ -66,12 +66,13 @@ func (s *Server) addPrompts() {
 		call := ToolCall{
 			Name: "task_list",
 			Arguments: core.ListTasksParams{
-				Status:  []string{"done"},
-				Sort:    []string{"updated"},
-				Reverse: true,
+				Status:       []string{"done"},
+				UpdatedSince: "7d",
+				Sort:         []string{"updated"},
+				Reverse:      true,
 			},
 		}
-		text := "Generate summary of completed tasks using:\n" + formatToolCall(call)
+		text := "Generate summary of the tasks completed in the last week using:\n" + formatToolCall(call)
 		return &mcp.GetPromptResult{
 			Messages: []*mcp.PromptMessage{{
 				Content: &mcp.TextContent{Text: text},
@@ -85,14 +86,12 @@ func (s *Server) addPrompts() {
 		Description: "Show high priority tasks that need to be done.",
 	}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 		call := ToolCall{
-			Name: "task_list",
+			Name: "task_next",
 			Arguments: core.ListTasksParams{
-				Status:  []string{"todo"},
-				Sort:    []string{"priority"},
-				Reverse: true,
+				Limit: 10,
 			},
 		}
-		text := "Show high priority tasks using:\n" + formatToolCall(call)
+		text := "Show the high priority tasks that are ready to be worked on using:\n" + formatToolCall(call)
 		return &mcp.GetPromptResult{
 			Messages: []*mcp.PromptMessage{{
 				Content: &mcp.TextContent{Text: text},
@@ -3,8 +3,13 @@ package mcp
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
 )
 
 //go:embed prompt-cli.md
@@ -17,6 +22,7 @@ const (
 	geminiInstructionsURI = "mcp://backlog/GEMINI.md"
 	claudeInstructionsURI = "mcp://backlog/CLAUDE.md"
 	agentInstructionsURI  = "mcp://backlog/AGENTS.md"
+	viewsURIPrefix        = "mcp://backlog/views/"
 )
 
 // addResources adds all MCP resources to the server
@@ -74,4 +80,42 @@ func (s *Server) addResources() {
 			},
 		}, nil
 	})
+
+	viewTemplate := &mcp.ResourceTemplate{
+		URITemplate: viewsURIPrefix + "{name}",
+		Name:        "views",
+		Description: "Tasks of a saved view defined in the views.yaml file of the tasks directory, as returned by task_list",
+		MIMEType:    "application/json",
+	}
+	s.mcpServer.AddResourceTemplate(viewTemplate, s.handler.readView)
+}
+
+// readView lists the tasks of the saved view named by the resource URI.
+func (h *handler) readView(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	name := strings.TrimPrefix(uri, viewsURIPrefix)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result, err := h.store.List(core.ListTasksParams{View: name})
+	if errors.Is(err, core.ErrNotFound) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("view %s: %v", name, err)
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("view %s: %v", name, err)
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "application/json",
+				Text:     string(b),
+			},
+		},
+	}, nil
 }
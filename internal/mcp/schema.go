@@ -20,11 +20,47 @@ func taskJSONSchema() *jsonschema.Schema {
 				{Type: "array", Items: &jsonschema.Schema{Type: "string"}},
 			}},
 			reflect.TypeFor[core.Priority](): {Type: "string"},
+			reflect.TypeFor[core.Duration](): {Type: "string"},
 		},
 	})
 	return schema
 }
 
+// setFieldsJSONSchema restricts the schema of an object of custom field values
+// to the fields declared by the repository. The values may also be null to
+// remove a field when editing.
+func setFieldsJSONSchema(schema *jsonschema.Schema, defs []core.FieldDef, nullable bool) {
+	schema.Properties = make(map[string]*jsonschema.Schema, len(defs))
+	schema.AdditionalProperties = &jsonschema.Schema{Not: &jsonschema.Schema{}}
+	for _, def := range defs {
+		var s *jsonschema.Schema
+		switch def.Type {
+		case core.FieldInt:
+			s = &jsonschema.Schema{Type: "integer"}
+		case core.FieldEnum:
+			s = &jsonschema.Schema{Type: "string"}
+			for _, v := range def.Values {
+				s.Enum = append(s.Enum, v)
+			}
+		case core.FieldDate:
+			s = &jsonschema.Schema{Type: "string", Format: "date"}
+		case core.FieldList:
+			s = &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "string"}}
+		default:
+			s = &jsonschema.Schema{Type: "string"}
+		}
+		if nullable {
+			s.Types = []string{s.Type, "null"}
+			s.Type = ""
+			if s.Enum != nil {
+				s.Enum = append(s.Enum, nil)
+			}
+		}
+		s.Description = def.Description
+		schema.Properties[def.Name] = s
+	}
+}
+
 // wrappedTasksJSONSchema returns a JSON schema for the wrapped Tasks array structure
 // that matches what's returned in StructuredContent: struct{ Tasks []core.Task }
 func wrappedTasksJSONSchema() *jsonschema.Schema {
@@ -62,3 +98,113 @@ func listResultJSONSchema() *jsonschema.Schema {
 		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
 	}
 }
+
+// deleteResultJSONSchema returns a JSON schema for core.DeleteResult
+// that matches what's returned in StructuredContent: core.DeleteResult
+func deleteResultJSONSchema() *jsonschema.Schema {
+	deleted := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"task":       taskJSONSchema(),
+			"path":       {Type: "string"},
+			"trash_path": {Type: "string"},
+		},
+		Required: []string{"task", "path", "trash_path"},
+	}
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"deleted":    {Type: "array", Items: deleted},
+			"references": {Type: "array", Items: taskJSONSchema()},
+			"stripped":   {Type: "boolean"},
+		},
+		Required:             []string{"deleted"},
+		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+	}
+}
+
+// moveResultJSONSchema returns a JSON schema for core.MoveResult
+// that matches what's returned in StructuredContent: core.MoveResult
+func moveResultJSONSchema() *jsonschema.Schema {
+	moved := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"old_id":   {Type: "string"},
+			"old_path": {Type: "string"},
+			"path":     {Type: "string"},
+			"task":     taskJSONSchema(),
+		},
+		Required: []string{"old_id", "old_path", "path", "task"},
+	}
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"moved":      {Type: "array", Items: moved},
+			"references": {Type: "array", Items: taskJSONSchema()},
+		},
+		Required:             []string{"moved"},
+		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+	}
+}
+
+// criticalPathResultJSONSchema returns a JSON schema for core.CriticalPathResult
+// that matches what's returned in StructuredContent: core.CriticalPathResult
+func criticalPathResultJSONSchema() *jsonschema.Schema {
+	blocking := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"task":   taskJSONSchema(),
+			"blocks": {Type: "integer"},
+		},
+		Required: []string{"task", "blocks"},
+	}
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"path":     {Type: "array", Items: blocking},
+			"blockers": {Type: "array", Items: blocking},
+		},
+		Required:             []string{"path", "blockers"},
+		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+	}
+}
+
+// searchResultJSONSchema returns a JSON schema for core.SearchResult
+// that matches what's returned in StructuredContent: core.SearchResult
+func searchResultJSONSchema() *jsonschema.Schema {
+	hit := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"task":    taskJSONSchema(),
+			"score":   {Type: "number"},
+			"snippet": {Type: "string"},
+		},
+		Required: []string{"task", "score", "snippet"},
+	}
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"hits":  {Type: "array", Items: hit},
+			"total": {Type: "integer"},
+		},
+		Required:             []string{"hits", "total"},
+		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+	}
+}
+
+// timerJSONSchema returns a JSON schema for core.Timer
+// that matches what's returned in StructuredContent: core.Timer
+func timerJSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"task":     taskJSONSchema(),
+			"assignee": {Type: "string"},
+			"started":  {Type: "string", Format: "date-time"},
+			"stopped":  {Type: "string", Format: "date-time"},
+			"elapsed":  {Type: "string"},
+		},
+		Required:             []string{"task", "started", "elapsed"},
+		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+	}
+}
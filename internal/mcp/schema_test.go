@@ -202,3 +202,90 @@ func validateStructuredContent(t *testing.T, schema *jsonschema.Schema, data any
 		t.Errorf("Schema validation failed: %v\nData: %s\nSchema: %+v", err, string(jsonData), string(j))
 	}
 }
+
+// TestEditStatusEnum verifies that the statuses of task_edit are the ones of the workflow.
+func TestEditStatusEnum(t *testing.T) {
+	is := is.New(t)
+	workflow, err := core.NewWorkflow([]string{"todo", "review", "done"}, nil)
+	is.NoErr(err)
+	store := core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog", core.WithWorkflow(workflow))
+	server, err := NewServer(store, false)
+	is.NoErr(err)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	_, err = server.mcpServer.Connect(t.Context(), serverTransport, nil)
+	is.NoErr(err)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "test"}, nil)
+	sess, err := client.Connect(t.Context(), clientTransport, nil)
+	is.NoErr(err)
+	defer func() { _ = sess.Close() }()
+
+	tools, err := sess.ListTools(t.Context(), &mcp.ListToolsParams{})
+	is.NoErr(err)
+	for _, tool := range tools.Tools {
+		if tool.Name != "task_edit" {
+			continue
+		}
+		b, err := json.Marshal(tool.InputSchema)
+		is.NoErr(err)
+		var schema jsonschema.Schema
+		is.NoErr(json.Unmarshal(b, &schema))
+		is.Equal(schema.Properties["new_status"].Enum, []any{"todo", "review", "done", "archived"})
+		return
+	}
+	t.Fatal("task_edit not found")
+}
+
+// TestFieldsSchema verifies that the custom fields are advertised in the input schemas.
+func TestFieldsSchema(t *testing.T) {
+	is := is.New(t)
+	defs, err := core.ParseFieldDefs(map[string]any{
+		"component": map[string]any{"type": "enum", "values": []any{"api", "ui"}},
+		"sprint":    map[string]any{"type": "int"},
+	})
+	is.NoErr(err)
+	store := core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog", core.WithFields(defs))
+	server, err := NewServer(store, false)
+	is.NoErr(err)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	_, err = server.mcpServer.Connect(t.Context(), serverTransport, nil)
+	is.NoErr(err)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "test"}, nil)
+	sess, err := client.Connect(t.Context(), clientTransport, nil)
+	is.NoErr(err)
+	defer func() { _ = sess.Close() }()
+
+	tools, err := sess.ListTools(t.Context(), &mcp.ListToolsParams{})
+	is.NoErr(err)
+	schemas := map[string]*jsonschema.Schema{}
+	for _, tool := range tools.Tools {
+		b, err := json.Marshal(tool.InputSchema)
+		is.NoErr(err)
+		var schema jsonschema.Schema
+		is.NoErr(json.Unmarshal(b, &schema))
+		schemas[tool.Name] = &schema
+	}
+
+	fields := schemas["task_create"].Properties["fields"]
+	is.Equal(fields.Properties["component"].Enum, []any{"api", "ui"})
+	is.Equal(fields.Properties["sprint"].Type, "integer")
+	batch := schemas["task_batch_create"].Properties["new_tasks"].Items.Properties["fields"]
+	is.Equal(batch.Properties["sprint"].Type, "integer")
+	set := schemas["task_edit"].Properties["set_fields"]
+	is.Equal(set.Properties["sprint"].Types, []string{"integer", "null"}) // null removes the field
+	is.Equal(set.Properties["component"].Enum, []any{"api", "ui", nil})
+
+	// the values are checked against the schema
+	_, err = sess.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "task_create",
+		Arguments: map[string]any{"title": "T", "description": "", "fields": map[string]any{"sprint": "soon"}},
+	})
+	is.True(err != nil)
+	res, err := sess.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "task_create",
+		Arguments: map[string]any{"title": "T", "description": "", "fields": map[string]any{"sprint": 3, "component": "ui"}},
+	})
+	is.NoErr(err)
+	is.True(!res.IsError)
+}
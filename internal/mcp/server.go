@@ -22,9 +22,27 @@ type TaskStore interface {
 	Get(id string) (core.Task, error)
 	Create(params core.CreateTaskParams) (core.Task, error)
 	Update(task *core.Task, params core.EditTaskParams) error
+	Edit(task *core.Task, params core.EditTaskParams) (core.EditResult, error)
 	List(params core.ListTasksParams) (core.ListResult, error)
+	Search(params core.SearchParams) (core.SearchResult, error)
+	Next(params core.ListTasksParams) (core.ListResult, error)
+	CriticalPath(params core.CriticalPathParams) (core.CriticalPathResult, error)
+	LogTime(params core.LogTimeParams) (core.Task, error)
+	EffortReport(params core.EffortReportParams) (core.EffortReport, error)
+	StartTimer(params core.StartTimerParams) (core.Timer, error)
+	StopTimer(params core.StopTimerParams) (core.Timer, error)
+	Views() ([]core.View, error)
+	Workflow() core.Workflow
+	Fields() []core.FieldDef
+	View(name string) (core.View, error)
 	Path(t core.Task) string
 	Archive(id core.TaskID) (string, error)
+	Unarchive(id core.TaskID) (core.Task, string, error)
+	Delete(params core.DeleteTaskParams) (core.DeleteResult, error)
+	RestoreFromTrash(id core.TaskID) (core.RestoreResult, error)
+	EmptyTrash() ([]string, error)
+	Move(params core.MoveTaskParams) (core.MoveResult, error)
+	Renumber(params core.RenumberParams) (core.RenumberResult, error)
 }
 
 // Server wraps the MCP server with backlog-specific functionality
@@ -50,6 +68,17 @@ func (h *handler) commit(id, title, path, oldPath, msg string) error {
 	return nil
 }
 
+// commitAll commits all the files changed by an operation on a task in a single commit.
+func (h *handler) commitAll(id, title string, paths []string, msg string) error {
+	if h.autoCommit {
+		commitMsg := fmt.Sprintf("feat(task): %s %s - \"%s\"", msg, id, title)
+		if err := commit.AddAll(commitMsg, paths...); err != nil {
+			return fmt.Errorf("auto-commit failed: %w", err)
+		}
+	}
+	return nil
+}
+
 // NewServer creates a new MCP server configured for backlog
 func NewServer(store TaskStore, autoCommit bool) (*Server, error) {
 	ver := version.Get()
@@ -137,5 +166,29 @@ func (s *Server) addTools() error {
 	if err := s.registerTaskArchive(); err != nil {
 		return err
 	}
+	if err := s.registerTaskUnarchive(); err != nil {
+		return err
+	}
+	if err := s.registerTaskDelete(); err != nil {
+		return err
+	}
+	if err := s.registerTaskMove(); err != nil {
+		return err
+	}
+	if err := s.registerTaskNext(); err != nil {
+		return err
+	}
+	if err := s.registerTaskCriticalPath(); err != nil {
+		return err
+	}
+	if err := s.registerTaskSearch(); err != nil {
+		return err
+	}
+	if err := s.registerTaskLogTime(); err != nil {
+		return err
+	}
+	if err := s.registerTaskTimers(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -186,6 +187,17 @@ func TestMCPHandlers(t *testing.T) {
 			txtContent, ok := result.Content[0].(*mcp.TextContent)
 			is.True(ok)
 			is.True(strings.Contains(txtContent.Text, "archived successfully"))
+
+			// Archived tasks are only listed on request
+			result, _, err = handler.list(ctx, req, core.ListTasksParams{Query: "Task to Archive"})
+			is.NoErr(err)
+			_, ok = result.StructuredContent.(core.ListResult)
+			is.True(!ok) // "No tasks found."
+			result, _, err = handler.list(ctx, req, core.ListTasksParams{Query: "Task to Archive", Archived: core.ArchivedOnly})
+			is.NoErr(err)
+			listResult, ok := result.StructuredContent.(core.ListResult)
+			is.True(ok)
+			is.Equal(len(listResult.Tasks), 1)
 		})
 
 		t.Run("archive_nonexistent_task", func(t *testing.T) {
@@ -200,6 +212,16 @@ func TestMCPHandlers(t *testing.T) {
 		})
 	})
 
+	t.Run("handleTaskUnarchive", func(t *testing.T) {
+		t.Run("unarchive_active_task", func(t *testing.T) {
+			is := is.New(t)
+
+			result, _, err := handler.unarchive(ctx, req, UnarchiveParams{ID: "T01"})
+			is.True(err != nil) // T01 is not archived
+			is.True(result == nil)
+		})
+	})
+
 	t.Run("handleTaskBatchCreate", func(t *testing.T) {
 		t.Run("batch_create_multiple_tasks", func(t *testing.T) {
 			is := is.New(t)
@@ -286,3 +308,256 @@ func TestMCPHandlers(t *testing.T) {
 		// })
 	})
 }
+
+func TestUnarchiveHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	createResult, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Task to Restore"})
+	is.NoErr(err)
+	createdTask, ok := createResult.StructuredContent.(core.Task)
+	is.True(ok)
+	_, _, err = h.archive(ctx, req, ArchiveParams{ID: createdTask.ID.String()})
+	is.NoErr(err)
+
+	result, _, err := h.unarchive(ctx, req, UnarchiveParams{ID: createdTask.ID.String()})
+	is.NoErr(err)
+	task, ok := result.StructuredContent.(core.Task)
+	is.True(ok)
+	is.Equal(task.Title, "Task to Restore")
+	is.Equal(task.Status, core.StatusTodo)
+}
+
+func TestDeleteHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	_, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Parent"})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Child", Parent: "T01"})
+	is.NoErr(err)
+
+	result, _, err := h.delete(ctx, req, core.DeleteTaskParams{ID: "T01"})
+	is.True(err != nil) // the task has subtasks
+	is.True(result == nil)
+
+	result, _, err = h.delete(ctx, req, core.DeleteTaskParams{ID: "T01", Cascade: true})
+	is.NoErr(err)
+	deleteResult, ok := result.StructuredContent.(core.DeleteResult)
+	is.True(ok)
+	is.Equal(len(deleteResult.Deleted), 2)
+	is.Equal(deleteResult.Deleted[0].Task.Title, "Parent")
+}
+
+func TestMoveHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	_, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Parent"})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Child", Parent: "T01"})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Other"})
+	is.NoErr(err)
+
+	result, _, err := h.move(ctx, req, core.MoveTaskParams{ID: "T01", To: "T01.01"})
+	is.True(err != nil) // into its own subtree
+	is.True(result == nil)
+
+	result, _, err = h.move(ctx, req, core.MoveTaskParams{ID: "T01", To: "T02"})
+	is.NoErr(err)
+	moveResult, ok := result.StructuredContent.(core.MoveResult)
+	is.True(ok)
+	is.Equal(len(moveResult.Moved), 2)
+	is.Equal(moveResult.Moved[0].Task.ID.String(), "02.01")
+	is.Equal(moveResult.Moved[1].Task.ID.String(), "02.01.01")
+}
+
+func TestNextHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	result, _, err := h.next(ctx, req, core.ListTasksParams{})
+	is.NoErr(err)
+	is.Equal(result.Content[0].(*mcp.TextContent).Text, "No tasks are ready to be worked on.")
+
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Blocker"})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Blocked", Priority: "high", Dependencies: []string{"T01"}})
+	is.NoErr(err)
+
+	result, _, err = h.next(ctx, req, core.ListTasksParams{})
+	is.NoErr(err)
+	listResult, ok := result.StructuredContent.(core.ListResult)
+	is.True(ok)
+	is.Equal(len(listResult.Tasks), 1)
+	is.Equal(listResult.Tasks[0].Title, "Blocker")
+}
+
+func TestCriticalPathHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	result, _, err := h.criticalPath(ctx, req, core.CriticalPathParams{})
+	is.NoErr(err)
+	is.Equal(result.Content[0].(*mcp.TextContent).Text, "No unfinished tasks.")
+
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Blocker"})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Blocked", Dependencies: []string{"T01"}})
+	is.NoErr(err)
+
+	result, _, err = h.criticalPath(ctx, req, core.CriticalPathParams{})
+	is.NoErr(err)
+	cpResult, ok := result.StructuredContent.(core.CriticalPathResult)
+	is.True(ok)
+	is.Equal(len(cpResult.Path), 2)
+	is.Equal(cpResult.Path[0].Task.Title, "Blocker")
+	is.Equal(len(cpResult.Blockers), 1)
+	is.Equal(cpResult.Blockers[0].Blocks, 1)
+}
+
+func TestLogTimeHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	_, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Release", Estimate: 3})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Changelog", Parent: "T01", Estimate: 1})
+	is.NoErr(err)
+
+	result, _, err := h.logTime(ctx, req, core.LogTimeParams{ID: "T01.01", Duration: "1h30m", Note: "Wrote it"})
+	is.NoErr(err)
+	task, ok := result.StructuredContent.(core.Task)
+	is.True(ok)
+	is.Equal(task.Spent, core.Duration(90*time.Minute))
+	is.Equal(task.History[len(task.History)-1].Type, "time_log")
+
+	_, _, err = h.logTime(ctx, req, core.LogTimeParams{ID: "T01", Duration: "soon"})
+	is.True(err != nil)
+
+	result, _, err = h.view(ctx, req, ViewParams{ID: "T01"})
+	is.NoErr(err)
+	task, ok = result.StructuredContent.(core.Task)
+	is.True(ok)
+	is.Equal(*task.Effort, core.Effort{Estimate: 4, Spent: core.Duration(90 * time.Minute)}) // rolled up
+}
+
+func TestTimerHandlers(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	_, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Release", Assigned: []string{"agent"}})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Changelog", Assigned: []string{"agent"}})
+	is.NoErr(err)
+
+	result, _, err := h.startTimer(ctx, req, core.StartTimerParams{ID: "T01"})
+	is.NoErr(err)
+	timer, ok := result.StructuredContent.(core.Timer)
+	is.True(ok)
+	is.Equal(timer.Assignee, "agent")
+	is.Equal(timer.Task.Status, core.StatusInProgress)
+
+	_, _, err = h.startTimer(ctx, req, core.StartTimerParams{ID: "T02"})
+	is.True(err != nil) // the agent already works on T01
+	_, _, err = h.startTimer(ctx, req, core.StartTimerParams{ID: "T02", Assignee: "other"})
+	is.True(err != nil) // not assigned to T02
+
+	result, _, err = h.stopTimer(ctx, req, core.StopTimerParams{})
+	is.NoErr(err)
+	timer, ok = result.StructuredContent.(core.Timer)
+	is.True(ok)
+	is.Equal(timer.Task.ID.String(), "01")
+	is.Equal(timer.Task.History[len(timer.Task.History)-1].Type, "timer_stopped")
+
+	_, _, err = h.stopTimer(ctx, req, core.StopTimerParams{})
+	is.True(err != nil) // no timer running
+}
+
+func TestSearchHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	_, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Fix login", Description: "The login page is blank"})
+	is.NoErr(err)
+
+	result, _, err := h.search(ctx, req, core.SearchParams{Query: "logn"})
+	is.NoErr(err)
+	searchResult, ok := result.StructuredContent.(core.SearchResult)
+	is.True(ok)
+	is.Equal(len(searchResult.Hits), 1)
+	is.Equal(searchResult.Hits[0].Snippet, "The **login** page is blank")
+
+	result, _, err = h.search(ctx, req, core.SearchParams{Query: "kubernetes"})
+	is.NoErr(err)
+	is.Equal(result.Content[0].(*mcp.TextContent).Text, "No tasks match the search.")
+}
+
+func TestViewResource(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	fs := afero.NewMemMapFs()
+	h := &handler{store: core.NewFileTaskStore(fs, ".backlog"), mu: &sync.Mutex{}}
+
+	_, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Crash", Labels: []string{"bug"}})
+	is.NoErr(err)
+	_, _, err = h.create(ctx, req, core.CreateTaskParams{Title: "Docs"})
+	is.NoErr(err)
+	is.NoErr(afero.WriteFile(fs, ".backlog/views.yaml", []byte("views:\n  bugs:\n    labels: [bug]\n"), 0o644))
+
+	result, _, err := h.list(ctx, req, core.ListTasksParams{View: "bugs"})
+	is.NoErr(err)
+	listResult, ok := result.StructuredContent.(core.ListResult)
+	is.True(ok)
+	is.Equal(len(listResult.Tasks), 1)
+
+	res, err := h.readView(ctx, &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: viewsURIPrefix + "bugs"}})
+	is.NoErr(err)
+	is.Equal(res.Contents[0].MIMEType, "application/json")
+	is.True(strings.Contains(res.Contents[0].Text, `"title":"Crash"`))
+	is.True(!strings.Contains(res.Contents[0].Text, `"title":"Docs"`))
+
+	_, err = h.readView(ctx, &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: viewsURIPrefix + "missing"}})
+	is.True(err != nil)
+}
+
+func TestEditConflictHandler(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	req := &mcp.CallToolRequest{}
+	h := &handler{store: core.NewFileTaskStore(afero.NewMemMapFs(), ".backlog"), mu: &sync.Mutex{}}
+
+	createResult, _, err := h.create(ctx, req, core.CreateTaskParams{Title: "Shared Task"})
+	is.NoErr(err)
+	created, ok := createResult.StructuredContent.(core.Task)
+	is.True(ok)
+	_, _, err = h.edit(ctx, req, core.EditTaskParams{ID: "T01", NewStatus: ptr("in-progress")})
+	is.NoErr(err)
+
+	// The edit is based on the version returned at creation, which is stale.
+	stale := created.Version()
+	result, _, err := h.edit(ctx, req, core.EditTaskParams{ID: "T01", NewStatus: ptr("done"), ExpectedUpdatedAt: &stale})
+	is.NoErr(err) // reported as a tool error the client can recover from
+	is.True(result.IsError)
+	txt, ok := result.Content[0].(*mcp.TextContent)
+	is.True(ok)
+	is.True(strings.Contains(txt.Text, "conflict"))
+}
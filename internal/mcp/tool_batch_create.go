@@ -15,6 +15,7 @@ func (s *Server) registerTaskBatchCreate() error {
 	if err != nil {
 		return err
 	}
+	setFieldsJSONSchema(inputSchema.Properties["new_tasks"].Items.Properties["fields"], s.handler.store.Fields(), false)
 	description := `Create a list of new tasks.
 The schema is a list of "task_create" input parameters.
 The task ID of each task is automatically generated. Returns the list of created task.
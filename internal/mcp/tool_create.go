@@ -15,8 +15,10 @@ func (s *Server) registerTaskCreate() error {
 	if err != nil {
 		return err
 	}
+	setFieldsJSONSchema(inputSchema.Properties["fields"], s.handler.store.Fields(), false)
 	description := `Create a new task. 
 The task ID is automatically generated. 
+The custom fields declared by the repository, if any, are set with 'fields'.
 Returns the created task.
 `
 	tool := &mcp.Tool{
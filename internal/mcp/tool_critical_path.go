@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+)
+
+func (s *Server) registerTaskCriticalPath() error {
+	inputSchema, err := jsonschema.For[core.CriticalPathParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Analyze the dependencies between the unfinished tasks to plan the work.
+	Returns the 'path': the longest chain of unfinished tasks depending on each other, starting with the task to do first,
+	and the 'blockers': the unfinished tasks blocking other tasks, ordered by the number of tasks they block directly or transitively.
+	Tasks that are done, cancelled, archived or in a final status of the workflow do not block anything.
+`
+	tool := &mcp.Tool{
+		Name:         "task_critical_path",
+		Title:        "Critical path",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: criticalPathResultJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.criticalPath)
+	return nil
+}
+
+func (h *handler) criticalPath(ctx context.Context, req *mcp.CallToolRequest, params core.CriticalPathParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result, err := h.store.CriticalPath(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("critical path: %v", err)
+	}
+
+	if len(result.Path) == 0 {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "No unfinished tasks."}}}, result, nil
+	}
+
+	res := &mcp.CallToolResult{StructuredContent: result}
+	return res, nil, nil
+}
@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+func (s *Server) registerTaskDelete() error {
+	inputSchema, err := jsonschema.For[core.DeleteTaskParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Delete a task.
+The task is moved to the trash directory, from where it can be restored with "backlog trash restore".
+A task with subtasks is only deleted with 'cascade', which deletes the subtasks as well.
+Dependencies of other tasks on the deleted tasks are reported, or removed with 'strip_references'.
+Returns the deleted tasks and the tasks referencing them.`
+
+	tool := &mcp.Tool{
+		Name:         "task_delete",
+		Title:        "Delete a task",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: deleteResultJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.delete)
+	return nil
+}
+
+func (h *handler) delete(ctx context.Context, req *mcp.CallToolRequest, params core.DeleteTaskParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result, err := h.store.Delete(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("delete: %v", err)
+	}
+	deleted := result.Deleted[0]
+	if err := h.commitAll(deleted.Task.ID.Name(), deleted.Task.Title, result.Paths(h.store.Path), "delete"); err != nil {
+		// Log the error but do not fail the delete
+		logging.Warn("auto-commit failed for task delete", "task_id", deleted.Task.ID, "error", err)
+	}
+	res := &mcp.CallToolResult{StructuredContent: result}
+	return res, nil, nil
+}
@@ -2,7 +2,9 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -15,8 +17,20 @@ func (s *Server) registerTaskEdit() error {
 	if err != nil {
 		return err
 	}
+	// The statuses depend on the workflow of the repository
+	workflow := s.handler.store.Workflow()
+	for _, name := range workflow.Names() {
+		inputSchema.Properties["new_status"].Enum = append(inputSchema.Properties["new_status"].Enum, name)
+	}
+	// So do the custom fields
+	setFieldsJSONSchema(inputSchema.Properties["set_fields"], s.handler.store.Fields(), true)
 	description := `Edit an existing task by its ID.
 This is a partial update, only the provided fields will be changed. 
+Set 'expected_updated_at' to the 'updated_at' (or 'created_at' if absent) of the task as you last read it
+to make sure you do not overwrite changes made by someone else in the meantime.
+If the task changed, the edit is rejected: read the task again with task_view and retry.
+The statuses and the transitions allowed between them depend on the workflow of the repository: a status change that is not allowed is rejected.
+The custom fields declared by the repository, if any, are set with 'set_fields', a null value removes a field.
 Returns the updated task.`
 
 	editTool := &mcp.Tool{
@@ -41,17 +55,19 @@ func (h *handler) edit(
 	if err != nil {
 		return nil, nil, fmt.Errorf("edit: %v", err)
 	}
-	oldPath := h.store.Path(task)
-	if err := h.store.Update(&task, params); err != nil {
+	result, err := h.store.Edit(&task, params)
+	if err != nil {
+		var conflict *core.EditConflictError
+		if errors.As(err, &conflict) {
+			// Not a failure of the server: the client can read the task again and retry.
+			text := fmt.Sprintf("edit: conflict: %v. Read the task again with task_view and retry the edit with 'expected_updated_at' set to %q.",
+				conflict, conflict.Actual.Format(time.RFC3339Nano))
+			return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: text}}}, nil, nil
+		}
 		return nil, nil, fmt.Errorf("edit: %v", err)
 	}
-	err = h.commit(
-		task.ID.Name(),
-		task.Title,
-		h.store.Path(task),
-		oldPath,
-		"edit",
-	)
+	paths := append([]string{h.store.Path(task)}, result.Paths(h.store.Path)...)
+	err = h.commitAll(task.ID.Name(), task.Title, paths, "edit")
 	if err != nil {
 		// Log the error but do not fail the edit
 		logging.Warn("auto-commit failed for task edit", "task_id", task.ID, "error", err)
@@ -17,6 +17,10 @@ func (s *Server) registerTaskList() error {
 	description := `List tasks, with optional filtering, sorting, and pagination. 
 	Returns a list of tasks with optional pagination metadata.
 	Use 'limit' and 'offset' parameters for pagination.
+	Archived tasks are excluded unless 'archived' is set to 'include' or 'only'.
+	Use 'overdue' for the unfinished tasks past their due date, and 'due_before' with sort 'due' for upcoming deadlines.
+	Use 'where' for conditions the other filters cannot express, e.g. 'label:bug or priority>=high', 'not status:done', 'title:refactor' or 'created>2025-09-01 and updated<7d'.
+	Use 'view' to run a saved view of the views.yaml file of the tasks directory, the other parameters given refine it. Views are also readable as mcp://backlog/views/<name> resources.
 `
 	tool := &mcp.Tool{
 		Name:         "task_list",
@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+func (s *Server) registerTaskLogTime() error {
+	inputSchema, err := jsonschema.For[core.LogTimeParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Log time spent on a task, such as 45m or 1h30m.
+The duration is added to the 'spent' field of the task and recorded in its history.
+The 'effort' of the tasks returned by task_list and task_view sums the estimates and the time spent over their subtasks.
+Returns the updated task.`
+	tool := &mcp.Tool{
+		Name:         "task_log_time",
+		Title:        "Log time",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: taskJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.logTime)
+	return nil
+}
+
+func (h *handler) logTime(ctx context.Context, req *mcp.CallToolRequest, params core.LogTimeParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	task, err := h.store.LogTime(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("log time: %v", err)
+	}
+	if err := h.commit(task.ID.Name(), task.Title, h.store.Path(task), "", "log time on"); err != nil {
+		// Log the error but do not fail the time log
+		logging.Warn("auto-commit failed for time log", "task_id", task.ID, "error", err)
+	}
+	res := &mcp.CallToolResult{StructuredContent: task}
+	return res, nil, nil
+}
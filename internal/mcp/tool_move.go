@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+func (s *Server) registerTaskMove() error {
+	inputSchema, err := jsonschema.For[core.MoveTaskParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Move a task under another parent task, or to the top level with 'to' set to "root".
+The task takes the next free ID under its new parent and its subtasks are renumbered along with it.
+Dependencies of other tasks on the moved tasks are updated to the new IDs.
+Returns the moved tasks with their old and new IDs, and the tasks whose dependencies were updated.`
+
+	tool := &mcp.Tool{
+		Name:         "task_move",
+		Title:        "Move a task",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: moveResultJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.move)
+	return nil
+}
+
+func (h *handler) move(ctx context.Context, req *mcp.CallToolRequest, params core.MoveTaskParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result, err := h.store.Move(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("move: %v", err)
+	}
+	moved := result.Moved[0]
+	if err := h.commitAll(moved.Task.ID.Name(), moved.Task.Title, result.Paths(h.store.Path), "move"); err != nil {
+		// Log the error but do not fail the move
+		logging.Warn("auto-commit failed for task move", "task_id", moved.Task.ID, "error", err)
+	}
+	res := &mcp.CallToolResult{StructuredContent: result}
+	return res, nil, nil
+}
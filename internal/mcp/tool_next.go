@@ -0,0 +1,48 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+)
+
+func (s *Server) registerTaskNext() error {
+	inputSchema, err := jsonschema.For[core.ListTasksParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `List the tasks that are ready to be worked on, answering "what should I do next?".
+	Returns the "todo" tasks whose dependencies are all done or cancelled, the first one being the best candidate.
+	Tasks are ordered by priority, then by dependency depth (tasks earlier in dependency chains first), then by age.
+	The other parameters filter the tasks as for task_list, e.g. 'assigned', 'labels' or 'parent'; 'status' and 'archived' are ignored.
+`
+	tool := &mcp.Tool{
+		Name:         "task_next",
+		Title:        "Next tasks to work on",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: listResultJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.next)
+	return nil
+}
+
+func (h *handler) next(ctx context.Context, req *mcp.CallToolRequest, params core.ListTasksParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	listResult, err := h.store.Next(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("next: %v", err)
+	}
+
+	if len(listResult.Tasks) == 0 {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "No tasks are ready to be worked on."}}}, listResult, nil
+	}
+
+	res := &mcp.CallToolResult{StructuredContent: listResult}
+	return res, nil, nil
+}
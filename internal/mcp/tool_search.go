@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+)
+
+func (s *Server) registerTaskSearch() error {
+	inputSchema, err := jsonschema.For[core.SearchParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Search tasks by relevance across their title, acceptance criteria, description, plan and notes.
+	Returns the matching tasks ranked by score, the most relevant first, each with a snippet where the matched words are highlighted with **.
+	Words match exactly, as a prefix, or with a typo. Use task_list with 'query' or 'where' to filter rather than rank.
+`
+	tool := &mcp.Tool{
+		Name:         "task_search",
+		Title:        "Search tasks",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: searchResultJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.search)
+	return nil
+}
+
+func (h *handler) search(ctx context.Context, req *mcp.CallToolRequest, params core.SearchParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result, err := h.store.Search(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("search: %v", err)
+	}
+
+	if len(result.Hits) == 0 {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "No tasks match the search."}}}, result, nil
+	}
+
+	res := &mcp.CallToolResult{StructuredContent: result}
+	return res, nil, nil
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+func (s *Server) registerTaskTimers() error {
+	startSchema, err := jsonschema.For[core.StartTimerParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Start working on a task: sets it in-progress and starts a timer, recorded in its history.
+Call it when starting a working session on a task, and task_stop_timer when done, so the time spent is tracked.
+Give the assignee working on the task unless the task has a single assignee. A task without assignee is assigned to them.
+Each assignee runs one timer at a time: stop the running timer before starting another one.
+Returns the timer with the updated task.`
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:         "task_start_timer",
+		Title:        "Start a timer",
+		Description:  description,
+		InputSchema:  startSchema,
+		OutputSchema: timerJSONSchema(),
+	}, s.handler.startTimer)
+
+	stopSchema, err := jsonschema.For[core.StopTimerParams](nil)
+	if err != nil {
+		return err
+	}
+	description = `Stop a running timer and add the elapsed time to the 'spent' field of the task, recorded in its history.
+Without parameters, stops the only running timer. The status of the task is not changed.
+Returns the timer, with the elapsed time and the updated task.`
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
+		Name:         "task_stop_timer",
+		Title:        "Stop a timer",
+		Description:  description,
+		InputSchema:  stopSchema,
+		OutputSchema: timerJSONSchema(),
+	}, s.handler.stopTimer)
+	return nil
+}
+
+func (h *handler) startTimer(ctx context.Context, req *mcp.CallToolRequest, params core.StartTimerParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timer, err := h.store.StartTimer(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("start timer: %v", err)
+	}
+	task := timer.Task
+	if err := h.commit(task.ID.Name(), task.Title, h.store.Path(task), "", "start timer on"); err != nil {
+		// Log the error but do not fail the timer
+		logging.Warn("auto-commit failed for timer start", "task_id", task.ID, "error", err)
+	}
+	res := &mcp.CallToolResult{StructuredContent: timer}
+	return res, nil, nil
+}
+
+func (h *handler) stopTimer(ctx context.Context, req *mcp.CallToolRequest, params core.StopTimerParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	timer, err := h.store.StopTimer(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("stop timer: %v", err)
+	}
+	task := timer.Task
+	if err := h.commit(task.ID.Name(), task.Title, h.store.Path(task), "", "stop timer on"); err != nil {
+		// Log the error but do not fail the timer
+		logging.Warn("auto-commit failed for timer stop", "task_id", task.ID, "error", err)
+	}
+	res := &mcp.CallToolResult{StructuredContent: timer}
+	return res, nil, nil
+}
@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/veggiemonk/backlog/internal/core"
+	"github.com/veggiemonk/backlog/internal/logging"
+)
+
+func (s *Server) registerTaskUnarchive() error {
+	inputSchema, err := jsonschema.For[UnarchiveParams](nil)
+	if err != nil {
+		return err
+	}
+	description := `Restore an archived task.
+The task is moved back from the archived directory and gets back the status it had before being archived.
+If its ID was reused in the meantime, the task is renumbered.
+Returns the restored task.`
+
+	tool := &mcp.Tool{
+		Name:         "task_unarchive",
+		Title:        "Unarchive a task",
+		Description:  description,
+		InputSchema:  inputSchema,
+		OutputSchema: taskJSONSchema(),
+	}
+	mcp.AddTool(s.mcpServer, tool, s.handler.unarchive)
+	return nil
+}
+
+type UnarchiveParams struct {
+	ID string `json:"id" jsonschema:"Required. The ID of the archived task to restore."`
+}
+
+func (h *handler) unarchive(ctx context.Context, req *mcp.CallToolRequest, params UnarchiveParams) (*mcp.CallToolResult, any, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	id, err := core.ParseTaskID(params.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unarchive: %v", err)
+	}
+	task, archivedPath, err := h.store.Unarchive(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unarchive: %v", err)
+	}
+	if err := h.commit(task.ID.Name(), task.Title, h.store.Path(task), archivedPath, "unarchive"); err != nil {
+		// Log the error but do not fail the unarchive
+		logging.Warn("auto-commit failed for task unarchive", "task_id", task.ID, "error", err)
+	}
+	res := &mcp.CallToolResult{StructuredContent: task}
+	return res, nil, nil
+}